@@ -0,0 +1,104 @@
+package adaptive
+
+import (
+	"math"
+	"time"
+)
+
+type AIMD struct {
+	BackoffRatio float64
+}
+
+func (a *AIMD) Update(limit float64, s Sample) float64 {
+	if s.Dropped {
+		ratio := a.BackoffRatio
+		if ratio == 0 {
+			ratio = 0.9
+		}
+		return limit * ratio
+	}
+
+	if float64(s.InFlight)*2 >= limit {
+		return limit + 1
+	}
+	return limit
+}
+
+type Vegas struct {
+	Alpha float64
+	Beta  float64
+
+	rttNoLoad time.Duration
+}
+
+func (v *Vegas) Update(limit float64, s Sample) float64 {
+	if v.rttNoLoad == 0 || s.RTT < v.rttNoLoad {
+		v.rttNoLoad = s.RTT
+	}
+
+	step := math.Max(1, math.Log10(limit))
+
+	if s.Dropped {
+		return limit - step
+	}
+
+	if float64(s.InFlight)*2 < limit || s.RTT == 0 {
+		return limit
+	}
+
+	alpha, beta := v.Alpha, v.Beta
+	if alpha == 0 {
+		alpha = 3
+	}
+	if beta == 0 {
+		beta = 6
+	}
+
+	queue := limit * (1 - float64(v.rttNoLoad)/float64(s.RTT))
+
+	switch {
+	case queue <= alpha:
+		return limit + step
+	case queue >= beta:
+		return limit - step
+	default:
+		return limit
+	}
+}
+
+type Gradient struct {
+	Tolerance float64
+	Smoothing float64
+
+	longRTT float64
+}
+
+func (g *Gradient) Update(limit float64, s Sample) float64 {
+	tolerance, smoothing := g.Tolerance, g.Smoothing
+	if tolerance == 0 {
+		tolerance = 1.5
+	}
+	if smoothing == 0 {
+		smoothing = 0.2
+	}
+
+	rtt := float64(s.RTT)
+	if g.longRTT == 0 {
+		g.longRTT = rtt
+	} else {
+		g.longRTT = g.longRTT*0.95 + rtt*0.05
+	}
+
+	if s.Dropped {
+		return limit * 0.9
+	}
+
+	if float64(s.InFlight)*2 < limit || rtt == 0 {
+		return limit
+	}
+
+	gradient := math.Max(0.5, math.Min(1, tolerance*g.longRTT/rtt))
+	newLimit := limit*gradient + math.Sqrt(limit)
+
+	return limit*(1-smoothing) + newLimit*smoothing
+}
@@ -0,0 +1,165 @@
+package adaptive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+var ErrLimitExceeded = errors.New("concurrency limit exceeded")
+
+type Sample struct {
+	RTT      time.Duration
+	InFlight int
+	Dropped  bool
+}
+
+type Algorithm interface {
+	Update(limit float64, s Sample) float64
+}
+
+type Config struct {
+	Algorithm    Algorithm
+	InitialLimit int
+	MinLimit     int
+	MaxLimit     int
+	Metrics      *metrics.Metrics
+}
+
+func (c Config) Validate() error {
+	if c.Algorithm == nil {
+		return errors.New("Algorithm must be set")
+	}
+	if c.InitialLimit <= 0 {
+		return errors.New("InitialLimit must be > 0")
+	}
+	if c.MinLimit < 0 || (c.MaxLimit > 0 && c.MaxLimit < c.MinLimit) {
+		return errors.New("MinLimit must be >= 0 and <= MaxLimit")
+	}
+	return nil
+}
+
+type Limiter struct {
+	mu       sync.Mutex
+	config   Config
+	limit    float64
+	inFlight int
+}
+
+func New(cfg Config) *Limiter {
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid adaptive limiter config: %v", err))
+	}
+
+	if cfg.MinLimit == 0 {
+		cfg.MinLimit = 1
+	}
+
+	l := &Limiter{
+		config: cfg,
+		limit:  float64(cfg.InitialLimit),
+	}
+	l.observe()
+
+	return l
+}
+
+type Token struct {
+	limiter  *Limiter
+	start    time.Time
+	inFlight int
+}
+
+func (l *Limiter) Acquire() (*Token, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight >= int(l.limit) {
+		return nil, ErrLimitExceeded
+	}
+
+	l.inFlight++
+	l.observe()
+
+	return &Token{limiter: l, start: time.Now(), inFlight: l.inFlight}, nil
+}
+
+func (t *Token) Success() {
+	t.release(false, true)
+}
+
+func (t *Token) Dropped() {
+	t.release(true, true)
+}
+
+func (t *Token) Ignore() {
+	t.release(false, false)
+}
+
+func (t *Token) release(dropped, update bool) {
+	l := t.limiter
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+
+	if update {
+		l.limit = l.config.Algorithm.Update(l.limit, Sample{
+			RTT:      time.Since(t.start),
+			InFlight: t.inFlight,
+			Dropped:  dropped,
+		})
+
+		if l.limit < float64(l.config.MinLimit) {
+			l.limit = float64(l.config.MinLimit)
+		}
+		if l.config.MaxLimit > 0 && l.limit > float64(l.config.MaxLimit) {
+			l.limit = float64(l.config.MaxLimit)
+		}
+	}
+
+	l.observe()
+}
+
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+func (l *Limiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inFlight
+}
+
+func (l *Limiter) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	token, err := l.Acquire()
+	if err != nil {
+		if l.config.Metrics != nil {
+			l.config.Metrics.ObserveLimitExceeded(metrics.NoState)
+		}
+		return nil, err
+	}
+
+	result, err := fn(ctx)
+	if err != nil {
+		token.Dropped()
+	} else {
+		token.Success()
+	}
+
+	return result, err
+}
+
+func (l *Limiter) observe() {
+	if l.config.Metrics != nil {
+		l.config.Metrics.SetConcurrencyLimit(int(l.limit))
+		l.config.Metrics.SetConcurrencyInFlight(l.inFlight)
+	}
+}
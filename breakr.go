@@ -2,10 +2,72 @@ package breakr
 
 import (
 	"context"
+	"github.com/genov8/breakr/adaptive"
+	"github.com/genov8/breakr/bulkhead"
 	"github.com/genov8/breakr/config"
 	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/ratelimit"
+	"time"
 )
 
+var (
+	ErrCircuitOpen = breakr.ErrCircuitOpen
+	ErrParentOpen  = breakr.ErrParentOpen
+
+	ErrBulkheadFull = bulkhead.ErrBulkheadFull
+	ErrRateLimited  = ratelimit.ErrRateLimited
+
+	ErrLimitExceeded = adaptive.ErrLimitExceeded
+	ErrThrottled     = breakr.ErrThrottled
+	ErrRampRejected  = breakr.ErrRampRejected
+
+	ErrExecutionTimeout = breakr.ErrExecutionTimeout
+	ErrTooManyAbandoned = breakr.ErrTooManyAbandoned
+
+	ErrInvalidSnapshot = breakr.ErrInvalidSnapshot
+)
+
+type CallOption = breakr.CallOption
+
+type CachedResult = breakr.CachedResult
+
+type Stats = breakr.Stats
+
+type TripReason = breakr.TripReason
+
+type Snapshot = breakr.Snapshot
+
+const (
+	TripFailures       = breakr.TripFailures
+	TripTimeouts       = breakr.TripTimeouts
+	TripErrors         = breakr.TripErrors
+	TripSlowCalls      = breakr.TripSlowCalls
+	TripBurnRate       = breakr.TripBurnRate
+	TripRecoveryFailed = breakr.TripRecoveryFailed
+	TripStrategy       = breakr.TripStrategy
+	TripShared         = breakr.TripShared
+)
+
+func WithTimeout(d time.Duration) CallOption {
+	return breakr.WithTimeout(d)
+}
+
+func WithBypass() CallOption {
+	return breakr.WithBypass()
+}
+
+func WithPriority(p config.Priority) CallOption {
+	return breakr.WithPriority(p)
+}
+
+func WithKey(key string) CallOption {
+	return breakr.WithKey(key)
+}
+
+func WithLabel(name, value string) CallOption {
+	return breakr.WithLabel(name, value)
+}
+
 type Breaker struct {
 	internal *breakr.Breaker
 }
@@ -14,6 +76,10 @@ func New(cfg config.Config) *Breaker {
 	return &Breaker{internal: breakr.New(cfg)}
 }
 
+func (b *Breaker) NewChild(cfg config.Config) *Breaker {
+	return &Breaker{internal: b.internal.NewChild(cfg)}
+}
+
 func (b *Breaker) Execute(fn func() (interface{}, error)) (interface{}, error) {
 	return b.internal.Execute(fn)
 }
@@ -22,6 +88,54 @@ func (b *Breaker) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (
 	return b.internal.ExecuteCtx(ctx, fn)
 }
 
+func (b *Breaker) ExecuteWith(ctx context.Context, fn func(ctx context.Context) (interface{}, error), opts ...CallOption) (interface{}, error) {
+	return b.internal.ExecuteWith(ctx, fn, opts...)
+}
+
+func (b *Breaker) ExecuteCached(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error), opts ...CallOption) (CachedResult, error) {
+	return b.internal.ExecuteCached(ctx, key, fn, opts...)
+}
+
+func (b *Breaker) ExecuteHedged(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return b.internal.ExecuteHedged(ctx, fn)
+}
+
 func (b *Breaker) State() string {
 	return b.internal.State().String()
 }
+
+func (b *Breaker) ParentOpen() bool {
+	return b.internal.ParentOpen()
+}
+
+func (b *Breaker) InFlight() int {
+	return b.internal.InFlight()
+}
+
+func (b *Breaker) RejectionProbability() float64 {
+	return b.internal.RejectionProbability()
+}
+
+func (b *Breaker) Abandoned() int {
+	return b.internal.Abandoned()
+}
+
+func (b *Breaker) ErrorBudgetRemaining() float64 {
+	return b.internal.ErrorBudgetRemaining()
+}
+
+func (b *Breaker) Snapshot() Snapshot {
+	return b.internal.Snapshot()
+}
+
+func (b *Breaker) Restore(s Snapshot) error {
+	return b.internal.Restore(s)
+}
+
+func (b *Breaker) Flush() {
+	b.internal.Flush()
+}
+
+func (b *Breaker) Stats() Stats {
+	return b.internal.Stats()
+}
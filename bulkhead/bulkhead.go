@@ -0,0 +1,133 @@
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+type Config struct {
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+	Metrics       *metrics.Metrics
+}
+
+func (c Config) Validate() error {
+	if c.MaxConcurrent <= 0 {
+		return errors.New("MaxConcurrent must be > 0")
+	}
+	if c.MaxQueue < 0 {
+		return errors.New("MaxQueue must be >= 0")
+	}
+	if c.QueueTimeout < 0 {
+		return errors.New("QueueTimeout must be >= 0")
+	}
+	return nil
+}
+
+type Bulkhead struct {
+	config Config
+	slots  chan struct{}
+	queue  chan struct{}
+}
+
+func New(cfg Config) *Bulkhead {
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid bulkhead config: %v", err))
+	}
+
+	b := &Bulkhead{
+		config: cfg,
+		slots:  make(chan struct{}, cfg.MaxConcurrent),
+	}
+
+	if cfg.MaxQueue > 0 {
+		b.queue = make(chan struct{}, cfg.MaxQueue)
+	}
+
+	return b
+}
+
+func (b *Bulkhead) Acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		b.observeInFlight()
+		return nil
+	default:
+	}
+
+	if b.queue == nil {
+		return ErrBulkheadFull
+	}
+
+	select {
+	case b.queue <- struct{}{}:
+	default:
+		return ErrBulkheadFull
+	}
+	defer func() { <-b.queue }()
+
+	var timeout <-chan time.Time
+	if b.config.QueueTimeout > 0 {
+		timer := time.NewTimer(b.config.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		b.observeInFlight()
+		return nil
+	case <-timeout:
+		return ErrBulkheadFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bulkhead) AcquireShare(ctx context.Context, share float64) error {
+	if share < 1 && float64(len(b.slots)) >= share*float64(cap(b.slots)) {
+		return ErrBulkheadFull
+	}
+	return b.Acquire(ctx)
+}
+
+func (b *Bulkhead) Release() {
+	<-b.slots
+	b.observeInFlight()
+}
+
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+func (b *Bulkhead) Queued() int {
+	if b.queue == nil {
+		return 0
+	}
+	return len(b.queue)
+}
+
+func (b *Bulkhead) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if err := b.Acquire(ctx); err != nil {
+		if errors.Is(err, ErrBulkheadFull) && b.config.Metrics != nil {
+			b.config.Metrics.ObserveRejected(metrics.NoState)
+		}
+		return nil, err
+	}
+	defer b.Release()
+
+	return fn(ctx)
+}
+
+func (b *Bulkhead) observeInFlight() {
+	if b.config.Metrics != nil {
+		b.config.Metrics.SetInFlight(len(b.slots))
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Size int
+	TTL  time.Duration
+}
+
+func (c Config) Validate() error {
+	if c.Size <= 0 {
+		return errors.New("Size must be > 0")
+	}
+	if c.TTL <= 0 {
+		return errors.New("TTL must be > 0")
+	}
+	return nil
+}
+
+type entry struct {
+	key      string
+	value    interface{}
+	storedAt time.Time
+}
+
+type Cache struct {
+	mu     sync.Mutex
+	config Config
+	items  map[string]*list.Element
+	order  *list.List
+}
+
+func New(cfg Config) *Cache {
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid cache config: %v", err))
+	}
+
+	return &Cache{
+		config: cfg,
+		items:  make(map[string]*list.Element, cfg.Size),
+		order:  list.New(),
+	}
+}
+
+func (c *Cache) Get(key string) (interface{}, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	e := el.Value.(*entry)
+	if time.Since(e.storedAt) > c.config.TTL {
+		c.remove(el)
+		return nil, time.Time{}, false
+	}
+
+	c.order.MoveToFront(el)
+	return e.value, e.storedAt, true
+}
+
+func (c *Cache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.storedAt = time.Now()
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, storedAt: time.Now()})
+
+	for c.order.Len() > c.config.Size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
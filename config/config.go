@@ -1,23 +1,156 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"github.com/genov8/breakr/adaptive"
 	"github.com/genov8/breakr/metrics"
 )
 
+type Mode int
+
+const (
+	ModeBreaker Mode = iota
+	ModeThrottle
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeBreaker:
+		return "breaker"
+	case ModeThrottle:
+		return "throttle"
+	default:
+		return "unknown"
+	}
+}
+
+type RampMode int
+
+const (
+	RampLinear RampMode = iota
+	RampExponential
+)
+
+func (m RampMode) String() string {
+	switch m {
+	case RampLinear:
+		return "linear"
+	case RampExponential:
+		return "exponential"
+	default:
+		return "unknown"
+	}
+}
+
+type TimeoutPolicy int
+
+const (
+	TimeoutMin TimeoutPolicy = iota
+	TimeoutCallerDeadline
+)
+
+func (p TimeoutPolicy) String() string {
+	switch p {
+	case TimeoutMin:
+		return "min"
+	case TimeoutCallerDeadline:
+		return "caller"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	FailureClassTimeout           = "timeout"
+	FailureClassConnectionRefused = "connection_refused"
+	FailureClassError             = "error"
+)
+
+type BurnRateWindow struct {
+	Short     time.Duration
+	Long      time.Duration
+	Threshold float64
+}
+
 type Config struct {
+	Name string
+
 	FailureThreshold int
 	ResetTimeout     time.Duration
 	ExecutionTimeout time.Duration
 	WindowSize       time.Duration
 	FailureCodes     []int
 	Metrics          *metrics.Metrics
+
+	FailureWeights map[string]float64
+	FailureWeight  func(err error) float64
+
+	TimeoutThreshold int
+	ErrorThreshold   int
+	SlowThreshold    int
+	SlowCallDuration time.Duration
+
+	TripStrategy TripStrategy
+
+	PropagateToParent bool
+
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+
+	RateLimit     float64
+	RateBurst     int
+	RateLimitWait bool
+
+	HedgeDelay      time.Duration
+	HedgePercentile float64
+	MaxHedges       int
+
+	ConcurrencyLimiter *adaptive.Limiter
+
+	Mode           Mode
+	ThrottleK      float64
+	ThrottleWindow time.Duration
+
+	RampDuration time.Duration
+	RampMode     RampMode
+
+	HealthCheck         func(ctx context.Context) error
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	HealthCheckCloses   bool
+
+	OnEvent func(Event)
+
+	AdmissionShares map[ShareScope]map[Priority]float64
+
+	CacheSize  int
+	CacheTTL   time.Duration
+	CacheFresh time.Duration
+
+	Policies []PolicySpec
+
+	TimeoutPolicy TimeoutPolicy
+
+	OnDiscard    func(result interface{}, err error)
+	MaxAbandoned int
+
+	WarmupCalls    int
+	WarmupDuration time.Duration
+
+	SLOTarget       float64
+	BurnRateWindows []BurnRateWindow
+
+	StateStore StateStore
+
+	SharedState SharedState
 }
 
 func (c Config) Validate() error {
-	if c.FailureThreshold <= 0 {
+	if c.FailureThreshold <= 0 && c.TripStrategy == nil {
 		return errors.New("FailureThreshold must be > 0")
 	}
 	if c.ResetTimeout <= 0 {
@@ -26,5 +159,85 @@ func (c Config) Validate() error {
 	if c.ExecutionTimeout <= 0 {
 		return errors.New("ExecutionTimeout must be > 0")
 	}
+	for _, w := range c.FailureWeights {
+		if w < 0 {
+			return errors.New("FailureWeights must be >= 0")
+		}
+	}
+	if c.TimeoutThreshold < 0 || c.ErrorThreshold < 0 || c.SlowThreshold < 0 {
+		return errors.New("TimeoutThreshold, ErrorThreshold and SlowThreshold must be >= 0")
+	}
+	if c.SlowThreshold > 0 && c.SlowCallDuration <= 0 {
+		return errors.New("SlowCallDuration must be > 0 when SlowThreshold is set")
+	}
+	if c.StateStore != nil && c.Name == "" {
+		return errors.New("Name must be set when StateStore is set")
+	}
+	if c.SharedState != nil && c.Name == "" {
+		return errors.New("Name must be set when SharedState is set")
+	}
+	if c.MaxConcurrent < 0 {
+		return errors.New("MaxConcurrent must be >= 0")
+	}
+	if c.MaxQueue < 0 || c.QueueTimeout < 0 {
+		return errors.New("MaxQueue and QueueTimeout must be >= 0")
+	}
+	if c.RateLimit < 0 || c.RateBurst < 0 {
+		return errors.New("RateLimit and RateBurst must be >= 0")
+	}
+	if c.HedgeDelay < 0 || c.MaxHedges < 0 {
+		return errors.New("HedgeDelay and MaxHedges must be >= 0")
+	}
+	if c.HedgePercentile < 0 || c.HedgePercentile >= 1 {
+		return errors.New("HedgePercentile must be in [0, 1)")
+	}
+	if c.Mode != ModeBreaker && c.Mode != ModeThrottle {
+		return errors.New("Mode must be ModeBreaker or ModeThrottle")
+	}
+	if c.ThrottleK < 0 || c.ThrottleWindow < 0 {
+		return errors.New("ThrottleK and ThrottleWindow must be >= 0")
+	}
+	if c.RampDuration < 0 {
+		return errors.New("RampDuration must be >= 0")
+	}
+	if c.RampMode != RampLinear && c.RampMode != RampExponential {
+		return errors.New("RampMode must be RampLinear or RampExponential")
+	}
+	if c.HealthCheckInterval < 0 || c.HealthCheckTimeout < 0 {
+		return errors.New("HealthCheckInterval and HealthCheckTimeout must be >= 0")
+	}
+	if c.TimeoutPolicy != TimeoutMin && c.TimeoutPolicy != TimeoutCallerDeadline {
+		return errors.New("TimeoutPolicy must be TimeoutMin or TimeoutCallerDeadline")
+	}
+	if c.WarmupCalls < 0 || c.WarmupDuration < 0 {
+		return errors.New("WarmupCalls and WarmupDuration must be >= 0")
+	}
+	if c.SLOTarget < 0 || c.SLOTarget >= 1 {
+		return errors.New("SLOTarget must be in [0, 1)")
+	}
+	if c.SLOTarget > 0 && len(c.BurnRateWindows) == 0 {
+		return errors.New("BurnRateWindows must be set when SLOTarget is set")
+	}
+	for _, w := range c.BurnRateWindows {
+		if w.Short <= 0 || w.Long < w.Short || w.Threshold <= 0 {
+			return errors.New("BurnRateWindows must have 0 < Short <= Long and Threshold > 0")
+		}
+	}
+	if c.MaxAbandoned < 0 {
+		return errors.New("MaxAbandoned must be >= 0")
+	}
+	if c.CacheSize < 0 || c.CacheFresh < 0 {
+		return errors.New("CacheSize and CacheFresh must be >= 0")
+	}
+	if c.CacheSize > 0 && c.CacheTTL <= 0 {
+		return errors.New("CacheTTL must be > 0 when CacheSize is set")
+	}
+	for _, shares := range c.AdmissionShares {
+		for _, share := range shares {
+			if share < 0 {
+				return errors.New("AdmissionShares must be >= 0")
+			}
+		}
+	}
 	return nil
 }
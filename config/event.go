@@ -0,0 +1,59 @@
+package config
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+	PriorityCritical
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+type ShareScope string
+
+const (
+	ShareHalfOpen ShareScope = "Half-Open"
+	ShareRamping  ShareScope = "Ramping"
+	ShareBulkhead ShareScope = "Bulkhead"
+)
+
+func ParsePriority(s string) (Priority, bool) {
+	for _, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical} {
+		if p.String() == s {
+			return p, true
+		}
+	}
+	return PriorityNormal, false
+}
+
+type Event struct {
+	State    string
+	Status   metrics.Status
+	Priority Priority
+	Key      string
+	Labels   map[string]string
+	Attempt  int
+	Duration time.Duration
+	Err      error
+}
@@ -0,0 +1,25 @@
+package config
+
+import "errors"
+
+func IsFailure(err error, codes []int) bool {
+	if err == nil {
+		return false
+	}
+
+	if len(codes) == 0 {
+		return true
+	}
+
+	var httpErr interface{ Code() int }
+	if errors.As(err, &httpErr) {
+		for _, code := range codes {
+			if httpErr.Code() == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
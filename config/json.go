@@ -19,6 +19,10 @@ func LoadConfigJSON(filePath string) (*Config, error) {
 
 	config := &Config{}
 
+	if v, ok := rawConfig["name"].(string); ok {
+		config.Name = v
+	}
+
 	if v, ok := rawConfig["failure_threshold"].(float64); ok {
 		config.FailureThreshold = int(v)
 	}
@@ -39,5 +43,123 @@ func LoadConfigJSON(filePath string) (*Config, error) {
 		}
 	}
 
+	if v, ok := rawConfig["propagate_to_parent"].(bool); ok {
+		config.PropagateToParent = v
+	}
+
+	if v, ok := rawConfig["max_concurrent"].(float64); ok {
+		config.MaxConcurrent = int(v)
+	}
+	if v, ok := rawConfig["max_queue"].(float64); ok {
+		config.MaxQueue = int(v)
+	}
+	if v, ok := rawConfig["queue_timeout"].(string); ok {
+		config.QueueTimeout, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := rawConfig["rate_limit"].(float64); ok {
+		config.RateLimit = v
+	}
+	if v, ok := rawConfig["rate_burst"].(float64); ok {
+		config.RateBurst = int(v)
+	}
+	if v, ok := rawConfig["rate_limit_wait"].(bool); ok {
+		config.RateLimitWait = v
+	}
+
+	if v, ok := rawConfig["hedge_delay"].(string); ok {
+		config.HedgeDelay, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["hedge_percentile"].(float64); ok {
+		config.HedgePercentile = v
+	}
+	if v, ok := rawConfig["max_hedges"].(float64); ok {
+		config.MaxHedges = int(v)
+	}
+
+	if v, ok := rawConfig["mode"].(string); ok && v == ModeThrottle.String() {
+		config.Mode = ModeThrottle
+	}
+	if v, ok := rawConfig["throttle_k"].(float64); ok {
+		config.ThrottleK = v
+	}
+	if v, ok := rawConfig["throttle_window"].(string); ok {
+		config.ThrottleWindow, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := rawConfig["ramp_duration"].(string); ok {
+		config.RampDuration, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["ramp_mode"].(string); ok && v == RampExponential.String() {
+		config.RampMode = RampExponential
+	}
+
+	if v, ok := rawConfig["health_check_interval"].(string); ok {
+		config.HealthCheckInterval, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["health_check_timeout"].(string); ok {
+		config.HealthCheckTimeout, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["health_check_closes"].(bool); ok {
+		config.HealthCheckCloses = v
+	}
+
+	if v, ok := rawConfig["admission_shares"].(map[string]interface{}); ok {
+		config.AdmissionShares = parseAdmissionShares(v)
+	}
+
+	if v, ok := rawConfig["cache_size"].(float64); ok {
+		config.CacheSize = int(v)
+	}
+	if v, ok := rawConfig["cache_ttl"].(string); ok {
+		config.CacheTTL, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["cache_fresh"].(string); ok {
+		config.CacheFresh, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := rawConfig["policies"].([]interface{}); ok {
+		config.Policies = parsePolicies(v)
+	}
+
+	if v, ok := rawConfig["timeout_policy"].(string); ok && v == TimeoutCallerDeadline.String() {
+		config.TimeoutPolicy = TimeoutCallerDeadline
+	}
+
+	if v, ok := rawConfig["max_abandoned"].(float64); ok {
+		config.MaxAbandoned = int(v)
+	}
+
+	if v, ok := rawConfig["warmup_calls"].(float64); ok {
+		config.WarmupCalls = int(v)
+	}
+	if v, ok := rawConfig["warmup_duration"].(string); ok {
+		config.WarmupDuration, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := toFloat(rawConfig["slo_target"]); ok {
+		config.SLOTarget = v
+	}
+	if v, ok := rawConfig["burn_rate_windows"].([]interface{}); ok {
+		config.BurnRateWindows = parseBurnRateWindows(v)
+	}
+
+	if v, ok := rawConfig["failure_weights"]; ok {
+		config.FailureWeights = parseFailureWeights(v)
+	}
+
+	if v, ok := rawConfig["timeout_threshold"].(float64); ok {
+		config.TimeoutThreshold = int(v)
+	}
+	if v, ok := rawConfig["error_threshold"].(float64); ok {
+		config.ErrorThreshold = int(v)
+	}
+	if v, ok := rawConfig["slow_threshold"].(float64); ok {
+		config.SlowThreshold = int(v)
+	}
+	if v, ok := rawConfig["slow_call_duration"].(string); ok {
+		config.SlowCallDuration, _ = time.ParseDuration(v)
+	}
+
 	return config, nil
 }
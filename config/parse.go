@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func parseInt(v interface{}) int {
+	f, _ := toFloat(v)
+	return int(f)
+}
+
+func parseDuration(v interface{}) time.Duration {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+	d, _ := time.ParseDuration(s)
+	return d
+}
+
+func parseAdmissionShares(raw map[string]interface{}) map[ShareScope]map[Priority]float64 {
+	result := make(map[ShareScope]map[Priority]float64, len(raw))
+
+	for scope, v := range raw {
+		shares, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		key := ShareScope(scope)
+		result[key] = make(map[Priority]float64, len(shares))
+		for name, share := range shares {
+			p, ok := ParsePriority(name)
+			if !ok {
+				continue
+			}
+			if f, ok := toFloat(share); ok {
+				result[key][p] = f
+			}
+		}
+	}
+
+	return result
+}
+
+func parseBurnRateWindows(raw []interface{}) []BurnRateWindow {
+	windows := make([]BurnRateWindow, 0, len(raw))
+
+	for _, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		threshold, _ := toFloat(m["threshold"])
+		windows = append(windows, BurnRateWindow{
+			Short:     parseDuration(m["short"]),
+			Long:      parseDuration(m["long"]),
+			Threshold: threshold,
+		})
+	}
+
+	return windows
+}
+
+func parseFailureWeights(raw interface{}) map[string]float64 {
+	weights := make(map[string]float64)
+
+	switch m := raw.(type) {
+	case map[string]interface{}:
+		for key, v := range m {
+			if f, ok := toFloat(v); ok {
+				weights[key] = f
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, v := range m {
+			if f, ok := toFloat(v); ok {
+				weights[fmt.Sprint(key)] = f
+			}
+		}
+	}
+
+	return weights
+}
@@ -0,0 +1,69 @@
+package config
+
+import "time"
+
+const (
+	PolicyTimeout   = "timeout"
+	PolicyRetry     = "retry"
+	PolicyBreaker   = "breaker"
+	PolicyBulkhead  = "bulkhead"
+	PolicyRateLimit = "rate_limit"
+	PolicyFallback  = "fallback"
+	PolicyCache     = "cache"
+)
+
+type PolicySpec struct {
+	Type string
+
+	Timeout time.Duration
+
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+
+	Rate  float64
+	Burst int
+	Wait  bool
+
+	Size int
+	TTL  time.Duration
+}
+
+func parsePolicies(raw []interface{}) []PolicySpec {
+	specs := make([]PolicySpec, 0, len(raw))
+
+	for _, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var spec PolicySpec
+		spec.Type, _ = m["type"].(string)
+
+		spec.Timeout = parseDuration(m["timeout"])
+		spec.MaxAttempts = parseInt(m["max_attempts"])
+		spec.InitialBackoff = parseDuration(m["initial_backoff"])
+		spec.MaxBackoff = parseDuration(m["max_backoff"])
+		spec.Multiplier, _ = toFloat(m["multiplier"])
+		spec.Jitter, _ = toFloat(m["jitter"])
+		spec.MaxConcurrent = parseInt(m["max_concurrent"])
+		spec.MaxQueue = parseInt(m["max_queue"])
+		spec.QueueTimeout = parseDuration(m["queue_timeout"])
+		spec.Rate, _ = toFloat(m["rate"])
+		spec.Burst = parseInt(m["burst"])
+		spec.Wait, _ = m["wait"].(bool)
+		spec.Size = parseInt(m["size"])
+		spec.TTL = parseDuration(m["ttl"])
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type SharedWindow struct {
+	Threshold float64
+	Window    time.Duration
+	OpenFor   time.Duration
+}
+
+type SharedState interface {
+	OpenUntil(name string) (time.Time, error)
+	RecordFailure(name string, weight float64, w SharedWindow) (time.Time, error)
+	Open(name string, until time.Time) error
+	Close(name string) error
+}
@@ -0,0 +1,10 @@
+package config
+
+import "errors"
+
+var ErrStateNotFound = errors.New("breaker state not found")
+
+type StateStore interface {
+	Load(name string) ([]byte, error)
+	Save(name string, data []byte) error
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+type Outcome struct {
+	Time     time.Time
+	Err      error
+	Weight   float64
+	Duration time.Duration
+}
+
+func (o Outcome) Failed() bool {
+	return o.Err != nil
+}
+
+type TripStrategy interface {
+	Record(o Outcome)
+	ShouldOpen(now time.Time) bool
+	ShouldClose(now time.Time) bool
+	Reset()
+}
@@ -20,6 +20,10 @@ func LoadConfigYAML(filePath string) (*Config, error) {
 
 	config := &Config{}
 
+	if v, ok := rawConfig["name"].(string); ok {
+		config.Name = v
+	}
+
 	if v, ok := rawConfig["failure_threshold"].(int); ok {
 		config.FailureThreshold = v
 	}
@@ -41,5 +45,129 @@ func LoadConfigYAML(filePath string) (*Config, error) {
 		}
 	}
 
+	if v, ok := rawConfig["propagate_to_parent"].(bool); ok {
+		config.PropagateToParent = v
+	}
+
+	if v, ok := rawConfig["max_concurrent"].(int); ok {
+		config.MaxConcurrent = v
+	}
+	if v, ok := rawConfig["max_queue"].(int); ok {
+		config.MaxQueue = v
+	}
+	if v, ok := rawConfig["queue_timeout"].(string); ok {
+		config.QueueTimeout, _ = time.ParseDuration(v)
+	}
+
+	switch v := rawConfig["rate_limit"].(type) {
+	case int:
+		config.RateLimit = float64(v)
+	case float64:
+		config.RateLimit = v
+	}
+	if v, ok := rawConfig["rate_burst"].(int); ok {
+		config.RateBurst = v
+	}
+	if v, ok := rawConfig["rate_limit_wait"].(bool); ok {
+		config.RateLimitWait = v
+	}
+
+	if v, ok := rawConfig["hedge_delay"].(string); ok {
+		config.HedgeDelay, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["hedge_percentile"].(float64); ok {
+		config.HedgePercentile = v
+	}
+	if v, ok := rawConfig["max_hedges"].(int); ok {
+		config.MaxHedges = v
+	}
+
+	if v, ok := rawConfig["mode"].(string); ok && v == ModeThrottle.String() {
+		config.Mode = ModeThrottle
+	}
+	switch v := rawConfig["throttle_k"].(type) {
+	case int:
+		config.ThrottleK = float64(v)
+	case float64:
+		config.ThrottleK = v
+	}
+	if v, ok := rawConfig["throttle_window"].(string); ok {
+		config.ThrottleWindow, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := rawConfig["ramp_duration"].(string); ok {
+		config.RampDuration, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["ramp_mode"].(string); ok && v == RampExponential.String() {
+		config.RampMode = RampExponential
+	}
+
+	if v, ok := rawConfig["health_check_interval"].(string); ok {
+		config.HealthCheckInterval, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["health_check_timeout"].(string); ok {
+		config.HealthCheckTimeout, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["health_check_closes"].(bool); ok {
+		config.HealthCheckCloses = v
+	}
+
+	if v, ok := rawConfig["admission_shares"].(map[string]interface{}); ok {
+		config.AdmissionShares = parseAdmissionShares(v)
+	}
+
+	if v, ok := rawConfig["cache_size"].(int); ok {
+		config.CacheSize = v
+	}
+	if v, ok := rawConfig["cache_ttl"].(string); ok {
+		config.CacheTTL, _ = time.ParseDuration(v)
+	}
+	if v, ok := rawConfig["cache_fresh"].(string); ok {
+		config.CacheFresh, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := rawConfig["policies"].([]interface{}); ok {
+		config.Policies = parsePolicies(v)
+	}
+
+	if v, ok := rawConfig["timeout_policy"].(string); ok && v == TimeoutCallerDeadline.String() {
+		config.TimeoutPolicy = TimeoutCallerDeadline
+	}
+
+	if v, ok := rawConfig["max_abandoned"].(int); ok {
+		config.MaxAbandoned = v
+	}
+
+	if v, ok := rawConfig["warmup_calls"].(int); ok {
+		config.WarmupCalls = v
+	}
+	if v, ok := rawConfig["warmup_duration"].(string); ok {
+		config.WarmupDuration, _ = time.ParseDuration(v)
+	}
+
+	if v, ok := toFloat(rawConfig["slo_target"]); ok {
+		config.SLOTarget = v
+	}
+	if v, ok := rawConfig["burn_rate_windows"].([]interface{}); ok {
+		config.BurnRateWindows = parseBurnRateWindows(v)
+	}
+
+	if v, ok := rawConfig["failure_weights"]; ok {
+		config.FailureWeights = parseFailureWeights(v)
+	}
+
+	if v, ok := rawConfig["timeout_threshold"].(int); ok {
+		config.TimeoutThreshold = v
+	}
+	if v, ok := rawConfig["error_threshold"].(int); ok {
+		config.ErrorThreshold = v
+	}
+	if v, ok := rawConfig["slow_threshold"].(int); ok {
+		config.SlowThreshold = v
+	}
+	if v, ok := rawConfig["slow_call_duration"].(string); ok {
+		config.SlowCallDuration, _ = time.ParseDuration(v)
+	}
+
 	return config, nil
 }
@@ -0,0 +1,376 @@
+package gossip
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+type Config struct {
+	ID       string
+	Peers    map[string]string
+	Quorum   int
+	Interval time.Duration
+	Client   *http.Client
+}
+
+func (c Config) Validate() error {
+	if c.ID == "" {
+		return errors.New("ID must be set")
+	}
+	for id, url := range c.Peers {
+		if id == "" || id == c.ID {
+			return errors.New("Peers must be keyed by distinct peer IDs")
+		}
+		if url == "" {
+			return fmt.Errorf("peer %q has no URL", id)
+		}
+	}
+	if c.Quorum < 0 || c.Quorum > len(c.Peers) {
+		return errors.New("Quorum must be between 0 and the number of peers")
+	}
+	if c.Interval < 0 {
+		return errors.New("Interval must be >= 0")
+	}
+	return nil
+}
+
+type record struct {
+	version     uint64
+	open        bool
+	until       time.Time
+	sharedUntil time.Time
+	failures    float64
+	received    time.Time
+}
+
+type failure struct {
+	at     time.Time
+	weight float64
+}
+
+type wireRecord struct {
+	Version     uint64  `json:"version"`
+	Open        bool    `json:"open"`
+	OpenForMs   int64   `json:"open_for_ms,omitempty"`
+	SharedForMs int64   `json:"shared_for_ms,omitempty"`
+	Failures    float64 `json:"failures,omitempty"`
+}
+
+type message struct {
+	From   string                           `json:"from"`
+	States map[string]map[string]wireRecord `json:"states"`
+}
+
+type Node struct {
+	config Config
+
+	mu       sync.Mutex
+	states   map[string]map[string]record
+	failures map[string][]failure
+
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func New(cfg Config) *Node {
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid gossip config: %v", err))
+	}
+	if cfg.Quorum == 0 {
+		cfg.Quorum = len(cfg.Peers)/2 + 1
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Interval}
+	}
+
+	return &Node{
+		config:   cfg,
+		states:   make(map[string]map[string]record),
+		failures: make(map[string][]failure),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (n *Node) Start() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stop != nil {
+		return
+	}
+
+	n.stop = make(chan struct{})
+	n.done = make(chan struct{})
+	go n.run(n.stop, n.done)
+}
+
+func (n *Node) Stop() {
+	n.mu.Lock()
+	stop, done := n.stop, n.done
+	n.stop, n.done = nil, nil
+	n.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (n *Node) OpenUntil(name string) (time.Time, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.openUntil(name, time.Now()), nil
+}
+
+func (n *Node) RecordFailure(name string, weight float64, w config.SharedWindow) (time.Time, error) {
+	now := time.Now()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	failures := append(n.failures[name], failure{at: now, weight: weight})
+	cutoff := now.Add(-w.Window)
+	for len(failures) > 0 && !failures[0].at.After(cutoff) {
+		failures = failures[1:]
+	}
+	n.failures[name] = failures
+
+	own := n.own(name)
+	own.failures = 0
+	for _, f := range failures {
+		own.failures += f.weight
+	}
+
+	total := own.failures
+	for id, r := range n.states[name] {
+		if _, member := n.config.Peers[id]; member && now.Sub(r.received) < w.Window {
+			total += r.failures
+		}
+	}
+
+	if w.Threshold > 0 && total >= w.Threshold && !now.Before(own.sharedUntil) {
+		own.sharedUntil = now.Add(w.OpenFor)
+	}
+	n.setOwn(name, own)
+
+	return n.openUntil(name, now), nil
+}
+
+func (n *Node) Open(name string, until time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	own := n.own(name)
+	own.open = true
+	own.until = until
+	n.setOwn(name, own)
+	return nil
+}
+
+func (n *Node) Close(name string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.failures, name)
+	n.setOwn(name, record{version: n.own(name).version})
+	return nil
+}
+
+func (n *Node) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var msg message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if _, ok := n.config.Peers[msg.From]; !ok {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		n.merge(msg, time.Now())
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(n.message(time.Now()))
+	})
+}
+
+func (n *Node) Gossip() error {
+	var errs []error
+	for id, url := range n.config.Peers {
+		if err := n.exchange(id, url); err != nil {
+			errs = append(errs, fmt.Errorf("gossip with %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Node) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(n.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-n.notify:
+		}
+
+		_ = n.Gossip()
+	}
+}
+
+func (n *Node) exchange(id, url string) error {
+	body, err := json.Marshal(n.message(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.config.Client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var msg message
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return err
+	}
+	if msg.From != id {
+		return fmt.Errorf("unexpected sender %q", msg.From)
+	}
+
+	n.merge(msg, time.Now())
+	return nil
+}
+
+func (n *Node) message(now time.Time) message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	msg := message{From: n.config.ID, States: make(map[string]map[string]wireRecord, len(n.states))}
+	for name, nodes := range n.states {
+		msg.States[name] = make(map[string]wireRecord, len(nodes))
+		for id, r := range nodes {
+			wr := wireRecord{Version: r.version, Failures: r.failures}
+			if r.open && now.Before(r.until) {
+				wr.Open = true
+				wr.OpenForMs = r.until.Sub(now).Milliseconds()
+			}
+			if now.Before(r.sharedUntil) {
+				wr.SharedForMs = r.sharedUntil.Sub(now).Milliseconds()
+			}
+			msg.States[name][id] = wr
+		}
+	}
+	return msg
+}
+
+func (n *Node) merge(msg message, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for name, nodes := range msg.States {
+		for id, wr := range nodes {
+			if _, member := n.config.Peers[id]; !member && id != n.config.ID {
+				continue
+			}
+
+			current, ok := n.states[name][id]
+			if ok && wr.Version <= current.version {
+				continue
+			}
+
+			if id == n.config.ID {
+				current.version = wr.Version
+				n.setOwn(name, current)
+				continue
+			}
+
+			r := record{version: wr.Version, failures: wr.Failures, received: now}
+			if wr.Open && wr.OpenForMs > 0 {
+				r.open = true
+				r.until = now.Add(time.Duration(wr.OpenForMs) * time.Millisecond)
+			}
+			if wr.SharedForMs > 0 {
+				r.sharedUntil = now.Add(time.Duration(wr.SharedForMs) * time.Millisecond)
+			}
+
+			if n.states[name] == nil {
+				n.states[name] = make(map[string]record)
+			}
+			n.states[name][id] = r
+		}
+	}
+}
+
+func (n *Node) own(name string) record {
+	return n.states[name][n.config.ID]
+}
+
+func (n *Node) setOwn(name string, r record) {
+	if n.states[name] == nil {
+		n.states[name] = make(map[string]record)
+	}
+
+	r.version++
+	n.states[name][n.config.ID] = r
+
+	select {
+	case n.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (n *Node) openUntil(name string, now time.Time) time.Time {
+	until := n.quorumUntil(name, now)
+	for id, r := range n.states[name] {
+		if _, member := n.config.Peers[id]; (member || id == n.config.ID) && r.sharedUntil.After(until) && now.Before(r.sharedUntil) {
+			until = r.sharedUntil
+		}
+	}
+	return until
+}
+
+func (n *Node) quorumUntil(name string, now time.Time) time.Time {
+	var untils []time.Time
+	for id, r := range n.states[name] {
+		if _, member := n.config.Peers[id]; member && r.open && now.Before(r.until) {
+			untils = append(untils, r.until)
+		}
+	}
+
+	if len(untils) < n.config.Quorum {
+		return time.Time{}
+	}
+
+	sort.Slice(untils, func(i, j int) bool {
+		return untils[i].After(untils[j])
+	})
+	return untils[n.config.Quorum-1]
+}
@@ -0,0 +1,36 @@
+package breakr
+
+import "sync/atomic"
+
+const (
+	callRunning int32 = iota
+	callCompleted
+	callAbandoned
+)
+
+func (b *Breaker) Abandoned() int {
+	return int(atomic.LoadInt64(&b.abandoned))
+}
+
+func (b *Breaker) abandon() {
+	n := atomic.AddInt64(&b.abandoned, 1)
+	if b.metrics != nil {
+		b.metrics.SetAbandoned(int(n))
+	}
+}
+
+func (b *Breaker) lateCompletion(result interface{}, err error) {
+	n := atomic.AddInt64(&b.abandoned, -1)
+	if b.metrics != nil {
+		b.metrics.SetAbandoned(int(n))
+		b.metrics.ObserveLateCompletion()
+	}
+
+	b.discard(result, err)
+}
+
+func (b *Breaker) discard(result interface{}, err error) {
+	if b.config.OnDiscard != nil {
+		b.config.OnDiscard(result, err)
+	}
+}
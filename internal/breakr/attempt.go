@@ -0,0 +1,16 @@
+package breakr
+
+import "context"
+
+type attemptKey struct{}
+
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func AttemptFrom(ctx context.Context) int {
+	if v, ok := ctx.Value(attemptKey{}).(int); ok {
+		return v
+	}
+	return 0
+}
@@ -2,22 +2,47 @@ package breakr
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/genov8/breakr/bulkhead"
+	"github.com/genov8/breakr/cache"
 	"github.com/genov8/breakr/config"
 	"github.com/genov8/breakr/metrics"
+	"github.com/genov8/breakr/ratelimit"
 )
 
 type Breaker struct {
+	abandoned int64
+	current   int32
+	rampEnd   int64
+
 	mu              sync.Mutex
 	state           State
 	config          config.Config
-	failures        []time.Time
+	failures        []failure
 	lastFailureTime time.Time
 	metrics         *metrics.Metrics
+	parent          *Breaker
+	bulkhead        *bulkhead.Bulkhead
+	limiter         *ratelimit.Limiter
+	latencies       *latencyWindow
+	throttle        *throttle
+	rampStart       time.Time
+	probing         bool
+	cache           *cache.Cache
+	created         time.Time
+	calls           int
+	slo             *sloTracker
+	tripReason      TripReason
+	openedAt        time.Time
+
+	pendingState  []byte
+	persistUrgent bool
+	persistWake   chan struct{}
+	writerDone    chan struct{}
 }
 
 func New(cfg config.Config) *Breaker {
@@ -26,8 +51,9 @@ func New(cfg config.Config) *Breaker {
 	}
 
 	b := &Breaker{
-		state:  Closed,
-		config: cfg,
+		state:   Closed,
+		config:  cfg,
+		created: time.Now(),
 	}
 
 	if cfg.Metrics != nil {
@@ -35,28 +61,151 @@ func New(cfg config.Config) *Breaker {
 		b.metrics.SetState(b.state.String())
 	}
 
+	if cfg.MaxConcurrent > 0 {
+		b.bulkhead = bulkhead.New(bulkhead.Config{
+			MaxConcurrent: cfg.MaxConcurrent,
+			MaxQueue:      cfg.MaxQueue,
+			QueueTimeout:  cfg.QueueTimeout,
+			Metrics:       cfg.Metrics,
+		})
+	}
+
+	if cfg.RateLimit > 0 {
+		b.limiter = ratelimit.New(ratelimit.Config{
+			Rate:  cfg.RateLimit,
+			Burst: cfg.RateBurst,
+			Wait:  cfg.RateLimitWait,
+		})
+	}
+
+	if cfg.Mode == config.ModeThrottle {
+		b.throttle = newThrottle(cfg.ThrottleK, cfg.ThrottleWindow, cfg.Metrics)
+	}
+
+	if cfg.SLOTarget > 0 {
+		b.slo = newSLOTracker(cfg.SLOTarget, cfg.BurnRateWindows)
+	}
+
+	if cfg.CacheSize > 0 {
+		b.cache = cache.New(cache.Config{
+			Size: cfg.CacheSize,
+			TTL:  cfg.CacheTTL,
+		})
+	}
+
+	if cfg.HedgePercentile > 0 {
+		b.latencies = newLatencyWindow()
+	}
+
+	if cfg.StateStore != nil {
+		b.loadState()
+	}
+
 	return b
 }
 
-func (b *Breaker) State() State {
+func (b *Breaker) NewChild(cfg config.Config) *Breaker {
+	child := New(cfg)
+	child.parent = b
+	return child
+}
+
+func (b *Breaker) Parent() *Breaker {
+	return b.parent
+}
+
+func (b *Breaker) ParentOpen() bool {
+	for p := b.parent; p != nil; p = p.parent {
+		if p.isOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Breaker) isOpen() bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	return b.state
+	return b.state == Open && !b.resetTimeoutElapsed()
+}
+
+func (b *Breaker) resetTimeoutElapsed() bool {
+	if b.config.HealthCheck != nil {
+		return false
+	}
+	return time.Since(b.openedAt) > b.config.ResetTimeout
+}
+
+func (b *Breaker) InFlight() int {
+	if b.bulkhead == nil {
+		return 0
+	}
+	return b.bulkhead.InFlight()
+}
+
+func (b *Breaker) RejectionProbability() float64 {
+	if b.throttle == nil {
+		return 0
+	}
+	return b.throttle.probability()
+}
+
+func (b *Breaker) State() State {
+	state := State(atomic.LoadInt32(&b.current))
+
+	switch {
+	case state != Open && time.Now().Before(b.sharedOpenUntil()):
+		return Open
+	case state == Ramping && time.Now().UnixNano() >= atomic.LoadInt64(&b.rampEnd):
+		return Closed
+	}
+	return state
 }
 
 func (b *Breaker) Execute(fn func() (interface{}, error)) (interface{}, error) {
 	return b.runWithContext(context.Background(), func(ctx context.Context) (interface{}, error) {
 		return fn()
-	})
+	}, callOptions{})
 }
 
 func (b *Breaker) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
-	return b.runWithContext(ctx, fn)
+	return b.runWithContext(ctx, fn, callOptions{})
+}
+
+func (b *Breaker) ExecuteWith(ctx context.Context, fn func(ctx context.Context) (interface{}, error), opts ...CallOption) (interface{}, error) {
+	return b.runWithContext(ctx, fn, newCallOptions(opts))
 }
 
 func (b *Breaker) reset() {
-	b.failures = []time.Time{}
-	b.state = Closed
+	switch b.state {
+	case HalfOpen:
+		if !b.strategyAllowsClose() {
+			return
+		}
+
+		b.failures = []failure{}
+		if b.slo != nil {
+			b.slo.reset()
+		}
+
+		if b.config.RampDuration > 0 {
+			b.startRamp(time.Now())
+			b.setState(Ramping)
+		} else {
+			b.setState(Closed)
+		}
+	case Ramping:
+		b.failures = []failure{}
+		b.finishRamp()
+	case Open:
+		return
+	default:
+		if b.config.WindowSize == 0 && len(b.failures) > 0 {
+			b.failures = []failure{}
+			b.persistLater()
+		}
+		b.setState(Closed)
+	}
 }
 
 func (b *Breaker) startResetTimer() {
@@ -67,31 +216,15 @@ func (b *Breaker) startResetTimer() {
 
 		if b.state == Open {
 			b.state = HalfOpen
+			atomic.StoreInt32(&b.current, int32(HalfOpen))
+			b.resetStrategy()
 			b.cleanUpFailures()
 		}
 	}()
 }
 
 func (b *Breaker) isFailure(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	if len(b.config.FailureCodes) == 0 {
-		return true
-	}
-
-	var httpErr interface{ Code() int }
-	if errors.As(err, &httpErr) {
-		for _, code := range b.config.FailureCodes {
-			if httpErr.Code() == code {
-				return true
-			}
-		}
-		return false
-	}
-
-	return true
+	return config.IsFailure(err, b.config.FailureCodes)
 }
 
 func (b *Breaker) cleanUpFailures() {
@@ -100,27 +233,53 @@ func (b *Breaker) cleanUpFailures() {
 	}
 
 	cutoff := time.Now().Add(-b.config.WindowSize)
-	newFailures := make([]time.Time, 0, len(b.failures))
+	newFailures := make([]failure, 0, len(b.failures))
 
-	for _, ts := range b.failures {
-		if ts.After(cutoff) {
-			newFailures = append(newFailures, ts)
+	for _, f := range b.failures {
+		if f.at.After(cutoff) {
+			newFailures = append(newFailures, f)
 		}
 	}
 
 	b.failures = newFailures
 }
 
-func (b *Breaker) shouldTrip() bool {
-	if b.config.WindowSize > 0 {
-		cutoff := time.Now().Add(-b.config.WindowSize)
-		count := 0
-		for _, ts := range b.failures {
-			if ts.After(cutoff) {
-				count++
-			}
+func (b *Breaker) shouldTrip() TripReason {
+	if b.config.TripStrategy != nil {
+		if b.config.TripStrategy.ShouldOpen(time.Now()) {
+			return TripStrategy
 		}
-		return count >= b.config.FailureThreshold
+		return TripNone
+	}
+
+	counts := b.failureCounts()
+
+	switch {
+	case b.config.TimeoutThreshold > 0 && counts.timeouts >= float64(b.config.TimeoutThreshold):
+		return TripTimeouts
+	case b.config.ErrorThreshold > 0 && counts.errors >= float64(b.config.ErrorThreshold):
+		return TripErrors
+	case b.config.SlowThreshold > 0 && counts.slow >= float64(b.config.SlowThreshold):
+		return TripSlowCalls
+	}
+
+	if b.slo != nil {
+		if b.slo.shouldTrip(time.Now(), b.config.FailureThreshold) {
+			return TripBurnRate
+		}
+		return TripNone
+	}
+
+	counted := 0.0
+	if b.config.TimeoutThreshold <= 0 {
+		counted += counts.timeouts
+	}
+	if b.config.ErrorThreshold <= 0 {
+		counted += counts.errors
+	}
+
+	if counted > 0 && counted >= float64(b.config.FailureThreshold) {
+		return TripFailures
 	}
-	return len(b.failures) >= b.config.FailureThreshold
+	return TripNone
 }
@@ -0,0 +1,74 @@
+package breakr
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+type CachedResult struct {
+	Value    interface{}
+	Stale    bool
+	StoredAt time.Time
+	Err      error
+}
+
+func (b *Breaker) ExecuteCached(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error), opts ...CallOption) (CachedResult, error) {
+	if b.cache == nil {
+		result, err := b.ExecuteWith(ctx, fn, opts...)
+		return CachedResult{Value: result}, err
+	}
+
+	if b.config.CacheFresh > 0 {
+		if value, storedAt, ok := b.cache.Get(key); ok && time.Since(storedAt) < b.config.CacheFresh {
+			b.observeCache(metrics.CacheHit)
+			return CachedResult{Value: value, StoredAt: storedAt}, nil
+		}
+	}
+
+	var failed int32
+	result, err := b.ExecuteWith(ctx, func(ctx context.Context) (interface{}, error) {
+		result, err := fn(ctx)
+		if b.isFailure(err) {
+			atomic.StoreInt32(&failed, 1)
+		}
+		return result, err
+	}, append(append([]CallOption(nil), opts...), WithKey(key))...)
+
+	if err == nil {
+		b.cache.Set(key, result)
+		b.observeCache(metrics.CacheMiss)
+		return CachedResult{Value: result, StoredAt: time.Now()}, nil
+	}
+
+	if !b.servesStale(ctx, err, atomic.LoadInt32(&failed) == 1) {
+		b.observeCache(metrics.CacheMiss)
+		return CachedResult{Err: err}, err
+	}
+
+	value, storedAt, ok := b.cache.Get(key)
+	if !ok {
+		b.observeCache(metrics.CacheMiss)
+		return CachedResult{Err: err}, err
+	}
+
+	b.observeCache(metrics.CacheStale)
+	return CachedResult{Value: value, Stale: true, StoredAt: storedAt, Err: err}, nil
+}
+
+func (b *Breaker) servesStale(ctx context.Context, err error, failed bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	return errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrExecutionTimeout) || failed
+}
+
+func (b *Breaker) observeCache(result metrics.CacheResult) {
+	if b.metrics != nil {
+		b.metrics.ObserveCache(result)
+	}
+}
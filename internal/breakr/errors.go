@@ -1,5 +1,21 @@
 package breakr
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+var ErrParentOpen = fmt.Errorf("parent %w", ErrCircuitOpen)
+
+var ErrRampRejected = fmt.Errorf("%w (ramping up)", ErrCircuitOpen)
+
+var ErrThrottled = errors.New("request throttled by circuit breaker")
+
+var ErrExecutionTimeout = fmt.Errorf("circuit breaker execution timeout: %w", context.DeadlineExceeded)
+
+var ErrTooManyAbandoned = errors.New("too many abandoned executions")
+
+var ErrInvalidSnapshot = errors.New("invalid circuit breaker snapshot")
@@ -2,43 +2,49 @@ package breakr
 
 import (
 	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
 	"time"
+
+	"github.com/genov8/breakr/adaptive"
+	"github.com/genov8/breakr/bulkhead"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/metrics"
+	"github.com/genov8/breakr/ratelimit"
 )
 
-func (b *Breaker) runWithContext(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+func (b *Breaker) runWithContext(ctx context.Context, fn func(ctx context.Context) (interface{}, error), call callOptions) (interface{}, error) {
 	start := time.Now()
+	call.attempt = AttemptFrom(ctx)
 
-	b.mu.Lock()
-	stateAtStart := b.state
-
-	if b.state == Open {
-		if time.Since(b.lastFailureTime) > b.config.ResetTimeout {
-			b.setState(HalfOpen)
-			b.cleanUpFailures()
-			stateAtStart = HalfOpen
-		} else {
-			b.mu.Unlock()
-
-			if b.metrics != nil {
-				b.metrics.ObserveBlocked(stateAtStart.String())
-			}
-			return nil, ErrCircuitOpen
-		}
+	if call.bypass {
+		return b.runBypassed(ctx, fn, call)
 	}
 
-	b.mu.Unlock()
-
-	if _, ok := ctx.Deadline(); !ok && b.config.ExecutionTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, b.config.ExecutionTimeout)
-		defer cancel()
+	stateAtStart, release, err := b.admit(ctx, call)
+	if err != nil {
+		return nil, err
 	}
 
+	callerCtx := ctx
+	ctx, cancel := b.withTimeout(ctx, call)
+	defer cancel()
+
 	resultChan := make(chan interface{}, 1)
 	errChan := make(chan error, 1)
 
+	var status int32
+
 	go func() {
 		result, err := fn(ctx)
+		release(callerCtx, ctx, err)
+
+		if !atomic.CompareAndSwapInt32(&status, callRunning, callCompleted) {
+			b.lateCompletion(result, err)
+			return
+		}
+
 		if err != nil {
 			errChan <- err
 		} else {
@@ -48,66 +54,370 @@ func (b *Breaker) runWithContext(ctx context.Context, fn func(ctx context.Contex
 
 	select {
 	case <-ctx.Done():
-		d := time.Since(start)
-
-		b.mu.Lock()
-		b.cleanUpFailures()
-		now := time.Now()
-		b.failures = append(b.failures, now)
-		b.lastFailureTime = now
-
-		if b.state == HalfOpen || b.shouldTrip() {
-			b.setState(Open)
-			b.startResetTimer()
+		if atomic.CompareAndSwapInt32(&status, callRunning, callAbandoned) {
+			b.abandon()
+		} else {
+			select {
+			case result := <-resultChan:
+				b.discard(result, nil)
+			case err := <-errChan:
+				b.discard(nil, err)
+			}
 		}
-		b.mu.Unlock()
 
-		if b.metrics != nil {
-			b.metrics.ObserveTimeout(stateAtStart.String(), d)
-		}
-		return nil, ctx.Err()
+		return nil, b.contextDone(callerCtx, ctx, call, stateAtStart, time.Since(start))
 
 	case result := <-resultChan:
 		d := time.Since(start)
 
-		b.mu.Lock()
-		b.reset()
-		b.mu.Unlock()
+		b.recordSuccess(d)
 
-		if b.metrics != nil {
-			b.metrics.ObserveSuccess(stateAtStart.String(), d)
+		if b.latencies != nil {
+			b.latencies.add(d)
 		}
 
+		b.report(call, stateAtStart, metrics.StatusSuccess, d, nil)
 		return result, nil
 
 	case err := <-errChan:
 		d := time.Since(start)
 
+		if ctx.Err() != nil {
+			return nil, b.contextDone(callerCtx, ctx, call, stateAtStart, d)
+		}
+
 		b.mu.Lock()
-		if !b.isFailure(err) {
-			b.mu.Unlock()
+		failure := b.isFailure(err)
+		if !failure {
+			b.calls++
+			b.recordSLO(false)
+		}
+		b.mu.Unlock()
 
-			if b.metrics != nil {
-				b.metrics.ObserveIgnored(stateAtStart.String(), d)
+		if !failure {
+			if b.throttle != nil {
+				b.throttle.accept()
 			}
+
+			b.report(call, stateAtStart, metrics.StatusIgnored, d, err)
 			return nil, err
 		}
 
+		b.recordFailure(err)
+
+		b.report(call, stateAtStart, metrics.StatusError, d, err)
+		return nil, err
+	}
+}
+
+func (b *Breaker) admit(ctx context.Context, call callOptions) (State, func(callerCtx, ctx context.Context, err error), error) {
+	if b.config.MaxAbandoned > 0 && b.Abandoned() >= b.config.MaxAbandoned {
+		state := b.State()
+		b.report(call, state, metrics.StatusAbandonedLimit, 0, ErrTooManyAbandoned)
+		return state, nil, ErrTooManyAbandoned
+	}
+
+	if b.ParentOpen() {
+		state := b.State()
+		b.report(call, state, metrics.StatusBlockedParent, 0, ErrParentOpen)
+		return state, nil, ErrParentOpen
+	}
+
+	if b.throttle != nil && !b.throttle.allow() {
+		state := b.State()
+		b.report(call, state, metrics.StatusThrottled, 0, ErrThrottled)
+		return state, nil, ErrThrottled
+	}
+
+	b.checkShared()
+
+	b.mu.Lock()
+	stateAtStart := b.state
+
+	if b.state == Open {
+		if b.resetTimeoutElapsed() {
+			b.setState(HalfOpen)
+			b.cleanUpFailures()
+			stateAtStart = HalfOpen
+		} else {
+			b.mu.Unlock()
+
+			b.report(call, stateAtStart, metrics.StatusBlocked, 0, ErrCircuitOpen)
+			return stateAtStart, nil, ErrCircuitOpen
+		}
+	}
+
+	if b.state == Ramping {
+		b.finishRamp()
+		stateAtStart = b.state
+	}
+
+	if b.state == HalfOpen || b.state == Ramping {
+		scope := config.ShareHalfOpen
+		if b.state == Ramping {
+			scope = config.ShareRamping
+		}
+
+		share := b.admissionShare(scope, call.priority)
+		rejectErr := ErrCircuitOpen
+		if b.state == Ramping {
+			share *= b.rampShare()
+			rejectErr = ErrRampRejected
+		}
+
+		if share < 1 && rand.Float64() >= share {
+			b.mu.Unlock()
+
+			b.report(call, stateAtStart, metrics.StatusBlocked, 0, rejectErr)
+			return stateAtStart, nil, rejectErr
+		}
+	}
+
+	b.mu.Unlock()
+
+	if b.limiter != nil {
+		if err := b.limiter.Acquire(ctx); err != nil {
+			if errors.Is(err, ratelimit.ErrRateLimited) {
+				b.report(call, stateAtStart, metrics.StatusRateLimited, 0, err)
+			}
+			return stateAtStart, nil, err
+		}
+	}
+
+	if b.bulkhead != nil {
+		share := b.admissionShare(config.ShareBulkhead, call.priority)
+		if err := b.bulkhead.AcquireShare(ctx, share); err != nil {
+			if errors.Is(err, bulkhead.ErrBulkheadFull) {
+				b.report(call, stateAtStart, metrics.StatusRejected, 0, err)
+			}
+			return stateAtStart, nil, err
+		}
+	}
+
+	var token *adaptive.Token
+	if b.config.ConcurrencyLimiter != nil {
+		var err error
+		if token, err = b.config.ConcurrencyLimiter.Acquire(); err != nil {
+			if b.bulkhead != nil {
+				b.bulkhead.Release()
+			}
+
+			b.report(call, stateAtStart, metrics.StatusLimitExceeded, 0, err)
+			return stateAtStart, nil, err
+		}
+	}
+
+	release := func(callerCtx, ctx context.Context, err error) {
+		if token != nil {
+			switch b.tokenOutcome(callerCtx, ctx, err) {
+			case outcomeDropped:
+				token.Dropped()
+			case outcomeIgnored:
+				token.Ignore()
+			default:
+				token.Success()
+			}
+		}
+
+		if b.bulkhead != nil {
+			b.bulkhead.Release()
+		}
+	}
+
+	return stateAtStart, release, nil
+}
+
+const (
+	outcomeSuccess = iota
+	outcomeDropped
+	outcomeIgnored
+)
+
+func (b *Breaker) tokenOutcome(callerCtx, ctx context.Context, err error) int {
+	if err == nil {
+		return outcomeSuccess
+	}
+
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return outcomeIgnored
+		}
+		if callerCtx.Err() == nil || b.config.TimeoutPolicy == config.TimeoutCallerDeadline {
+			return outcomeDropped
+		}
+		return outcomeIgnored
+	}
+
+	if b.isFailure(err) {
+		return outcomeDropped
+	}
+	return outcomeIgnored
+}
+
+func (b *Breaker) runBypassed(ctx context.Context, fn func(ctx context.Context) (interface{}, error), call callOptions) (interface{}, error) {
+	start := time.Now()
+
+	ctx, cancel := b.withTimeout(ctx, call)
+	defer cancel()
+
+	result, err := fn(ctx)
+
+	b.report(call, b.State(), metrics.StatusBypassed, time.Since(start), err)
+	return result, err
+}
+
+func (b *Breaker) contextDone(callerCtx, ctx context.Context, call callOptions, state State, d time.Duration) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		b.report(call, state, metrics.StatusCancelled, d, ctx.Err())
+		return ctx.Err()
+	}
+
+	if callerCtx.Err() == nil {
+		b.recordFailure(ErrExecutionTimeout)
+
+		b.report(call, state, metrics.StatusTimeout, d, ErrExecutionTimeout)
+		return ErrExecutionTimeout
+	}
+
+	if b.config.TimeoutPolicy == config.TimeoutCallerDeadline {
+		b.recordFailure(callerCtx.Err())
+	}
+
+	b.report(call, state, metrics.StatusDeadlineExceeded, d, callerCtx.Err())
+	return callerCtx.Err()
+}
+
+func (b *Breaker) withTimeout(ctx context.Context, call callOptions) (context.Context, context.CancelFunc) {
+	timeout := b.config.ExecutionTimeout
+	if call.timeout > 0 {
+		timeout = call.timeout
+	}
+
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if b.config.TimeoutPolicy == config.TimeoutCallerDeadline || time.Until(deadline) <= timeout {
+			return ctx, func() {}
+		}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (b *Breaker) report(call callOptions, state State, status metrics.Status, d time.Duration, err error) {
+	if b.metrics != nil {
+		switch status {
+		case metrics.StatusSuccess:
+			b.metrics.ObserveSuccess(state.String(), d)
+		case metrics.StatusError:
+			b.metrics.ObserveError(state.String(), d)
+		case metrics.StatusTimeout:
+			b.metrics.ObserveTimeout(state.String(), d)
+		case metrics.StatusDeadlineExceeded:
+			b.metrics.ObserveDeadlineExceeded(state.String(), d)
+		case metrics.StatusCancelled:
+			b.metrics.ObserveCancelled(state.String(), d)
+		case metrics.StatusIgnored:
+			b.metrics.ObserveIgnored(state.String(), d)
+		case metrics.StatusBypassed:
+			b.metrics.ObserveBypassed(state.String(), d)
+		case metrics.StatusBlocked:
+			b.metrics.ObserveBlocked(state.String())
+		case metrics.StatusBlockedParent:
+			b.metrics.ObserveBlockedByParent(state.String())
+		case metrics.StatusThrottled:
+			b.metrics.ObserveThrottled(state.String())
+		case metrics.StatusRateLimited:
+			b.metrics.ObserveRateLimited(state.String())
+		case metrics.StatusRejected:
+			b.metrics.ObserveRejected(state.String())
+		case metrics.StatusLimitExceeded:
+			b.metrics.ObserveLimitExceeded(state.String())
+		case metrics.StatusAbandonedLimit:
+			b.metrics.ObserveAbandonedLimit(state.String())
+		}
+
+		b.metrics.ObservePriority(call.priority.String(), status)
+	}
+
+	if b.config.OnEvent != nil {
+		b.config.OnEvent(config.Event{
+			State:    state.String(),
+			Status:   status,
+			Priority: call.priority,
+			Key:      call.key,
+			Labels:   call.labels,
+			Attempt:  call.attempt,
+			Duration: d,
+			Err:      err,
+		})
+	}
+}
+
+func (b *Breaker) recordSuccess(d time.Duration) {
+	if b.throttle != nil {
+		b.throttle.accept()
+	} else {
+		b.mu.Lock()
+		before := b.state
+		b.calls++
+		b.recordSLO(false)
+
+		now := time.Now()
+		b.recordOutcome(config.Outcome{Time: now, Duration: d})
+
+		switch {
+		case b.config.TripStrategy != nil && b.state == Closed:
+			if !b.evaluateTrip() {
+				b.reset()
+			}
+		case b.config.TripStrategy == nil && b.state == Closed && b.isSlow(d):
+			b.cleanUpFailures()
+			b.failures = append(b.failures, failure{at: now, weight: 1, category: categorySlow})
+			if !b.evaluateTrip() {
+				b.persistLater()
+			}
+		default:
+			b.reset()
+		}
+		after := b.state
+		b.mu.Unlock()
+
+		b.shareSuccess(before, after)
+	}
+
+	if b.parent != nil && b.config.PropagateToParent {
+		b.parent.recordSuccess(d)
+	}
+}
+
+func (b *Breaker) recordFailure(err error) {
+	if b.throttle == nil {
+		b.mu.Lock()
+		before := b.state
+		b.calls++
+		b.recordSLO(true)
 		b.cleanUpFailures()
 		now := time.Now()
-		b.failures = append(b.failures, now)
+		weight := b.failureWeight(err)
+		b.failures = append(b.failures, failure{at: now, weight: weight, category: categorize(err)})
 		b.lastFailureTime = now
+		b.recordOutcome(config.Outcome{Time: now, Err: err, Weight: weight})
 
-		if b.state == HalfOpen || b.shouldTrip() {
-			b.setState(Open)
-			b.startResetTimer()
+		if b.state == HalfOpen || b.state == Ramping {
+			b.trip(TripRecoveryFailed)
+		} else if !b.evaluateTrip() {
+			b.persistLater()
 		}
+		after, openedAt := b.state, b.openedAt
 		b.mu.Unlock()
 
-		if b.metrics != nil {
-			b.metrics.ObserveError(stateAtStart.String(), d)
-		}
+		b.shareFailure(before, after, openedAt, weight)
+	}
 
-		return nil, err
+	if b.parent != nil && b.config.PropagateToParent {
+		b.parent.recordFailure(err)
 	}
 }
@@ -0,0 +1,124 @@
+package breakr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	latencySamples    = 100
+	minLatencySamples = 10
+)
+
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newLatencyWindow() *latencyWindow {
+	return &latencyWindow{samples: make([]time.Duration, 0, latencySamples)}
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.samples) < latencySamples {
+		w.samples = append(w.samples, d)
+		return
+	}
+
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % latencySamples
+}
+
+func (w *latencyWindow) percentile(p float64) (time.Duration, bool) {
+	w.mu.Lock()
+	sorted := append([]time.Duration(nil), w.samples...)
+	w.mu.Unlock()
+
+	if len(sorted) < minLatencySamples {
+		return 0, false
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(p*float64(len(sorted)-1))], true
+}
+
+func (b *Breaker) ExecuteHedged(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	delay := b.hedgeDelay()
+	if delay <= 0 {
+		return b.runWithContext(ctx, fn, callOptions{})
+	}
+
+	maxHedges := b.config.MaxHedges
+	if maxHedges == 0 {
+		maxHedges = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+
+	results := make(chan outcome, maxHedges+1)
+	launch := func() {
+		go func() {
+			result, err := b.runWithContext(ctx, fn, callOptions{})
+			results <- outcome{result: result, err: err}
+		}()
+	}
+
+	launch()
+	hedges, pending := 0, 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	hedgeC := timer.C
+
+	var lastErr error
+	for {
+		select {
+		case o := <-results:
+			pending--
+			if o.err == nil {
+				return o.result, nil
+			}
+
+			lastErr = o.err
+			if pending == 0 {
+				return nil, lastErr
+			}
+
+		case <-hedgeC:
+			if hedges >= maxHedges || !b.canHedge() {
+				hedgeC = nil
+				continue
+			}
+
+			launch()
+			hedges++
+			pending++
+			timer.Reset(delay)
+		}
+	}
+}
+
+func (b *Breaker) canHedge() bool {
+	return b.State() == Closed && !b.ParentOpen()
+}
+
+func (b *Breaker) hedgeDelay() time.Duration {
+	if b.latencies != nil {
+		if d, ok := b.latencies.percentile(b.config.HedgePercentile); ok {
+			return d
+		}
+	}
+	return b.config.HedgeDelay
+}
@@ -0,0 +1,59 @@
+package breakr
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+type callOptions struct {
+	timeout  time.Duration
+	bypass   bool
+	priority config.Priority
+	key      string
+	labels   map[string]string
+	attempt  int
+}
+
+type CallOption func(*callOptions)
+
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+func WithBypass() CallOption {
+	return func(o *callOptions) {
+		o.bypass = true
+	}
+}
+
+func WithPriority(p config.Priority) CallOption {
+	return func(o *callOptions) {
+		o.priority = p
+	}
+}
+
+func WithKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.key = key
+	}
+}
+
+func WithLabel(name, value string) CallOption {
+	return func(o *callOptions) {
+		if o.labels == nil {
+			o.labels = make(map[string]string)
+		}
+		o.labels[name] = value
+	}
+}
+
+func newCallOptions(opts []CallOption) callOptions {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,126 @@
+package breakr
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+func (b *Breaker) loadState() {
+	data, err := b.config.StateStore.Load(b.config.Name)
+	if err != nil {
+		if !errors.Is(err, config.ErrStateNotFound) {
+			b.stateStoreError("load")
+		}
+		return
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		b.stateStoreError("load")
+		return
+	}
+
+	if err := b.Restore(s); err != nil {
+		b.stateStoreError("load")
+	}
+}
+
+const persistDelay = 100 * time.Millisecond
+
+func (b *Breaker) persist() {
+	b.queueState(true)
+}
+
+func (b *Breaker) persistLater() {
+	b.queueState(false)
+}
+
+func (b *Breaker) queueState(urgent bool) {
+	if b.config.StateStore == nil {
+		return
+	}
+
+	data, err := json.Marshal(b.snapshot())
+	if err != nil {
+		b.stateStoreError("save")
+		return
+	}
+
+	b.pendingState = data
+	if urgent {
+		b.persistUrgent = true
+		b.wakeWriter()
+	}
+
+	if b.writerDone == nil {
+		b.writerDone = make(chan struct{})
+		b.persistWake = make(chan struct{}, 1)
+		go b.writeState(b.writerDone, b.persistWake)
+	}
+}
+
+func (b *Breaker) wakeWriter() {
+	select {
+	case b.persistWake <- struct{}{}:
+	default:
+	}
+}
+
+func (b *Breaker) writeState(done chan struct{}, wake chan struct{}) {
+	defer close(done)
+
+	for {
+		b.mu.Lock()
+		if b.pendingState == nil {
+			b.writerDone = nil
+			b.mu.Unlock()
+			return
+		}
+		urgent := b.persistUrgent
+		b.mu.Unlock()
+
+		if !urgent {
+			timer := time.NewTimer(persistDelay)
+			select {
+			case <-timer.C:
+			case <-wake:
+				timer.Stop()
+			}
+		}
+
+		b.mu.Lock()
+		data := b.pendingState
+		b.pendingState, b.persistUrgent = nil, false
+		b.mu.Unlock()
+
+		if data == nil {
+			continue
+		}
+		if err := b.config.StateStore.Save(b.config.Name, data); err != nil {
+			b.stateStoreError("save")
+		}
+	}
+}
+
+func (b *Breaker) Flush() {
+	b.mu.Lock()
+	done := b.writerDone
+	if done != nil {
+		b.persistUrgent = true
+		b.wakeWriter()
+	}
+	b.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func (b *Breaker) stateStoreError(op string) {
+	if b.metrics != nil {
+		b.metrics.ObserveStateStoreError(op)
+	}
+}
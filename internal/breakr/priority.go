@@ -0,0 +1,16 @@
+package breakr
+
+import "github.com/genov8/breakr/config"
+
+func (b *Breaker) admissionShare(scope config.ShareScope, p config.Priority) float64 {
+	shares, ok := b.config.AdmissionShares[scope]
+	if !ok {
+		return 1
+	}
+
+	share, ok := shares[p]
+	if !ok {
+		return 1
+	}
+	return share
+}
@@ -0,0 +1,91 @@
+package breakr
+
+import (
+	"context"
+	"time"
+)
+
+func (b *Breaker) scheduleRecovery() {
+	if b.config.HealthCheck == nil {
+		b.startResetTimer()
+		return
+	}
+
+	if b.probing {
+		return
+	}
+	b.probing = true
+
+	go b.runProbes()
+}
+
+func (b *Breaker) runProbes() {
+	interval := b.config.HealthCheckInterval
+	if interval == 0 {
+		interval = b.config.ResetTimeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.mu.Lock()
+		if b.state != Open {
+			b.probing = false
+			b.mu.Unlock()
+			return
+		}
+		b.mu.Unlock()
+
+		if !b.probe() {
+			continue
+		}
+
+		b.mu.Lock()
+		b.probing = false
+		recovered := b.state == Open
+		if recovered {
+			b.failures = []failure{}
+			b.setState(HalfOpen)
+			if b.config.HealthCheckCloses {
+				b.reset()
+			}
+		}
+		after := b.state
+		b.mu.Unlock()
+
+		if recovered {
+			b.shareSuccess(HalfOpen, after)
+		}
+		return
+	}
+}
+
+func (b *Breaker) probe() bool {
+	timeout := b.config.HealthCheckTimeout
+	if timeout == 0 {
+		timeout = b.config.ExecutionTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- b.config.HealthCheck(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if b.metrics != nil {
+		b.metrics.ObserveHealthCheck(err == nil, time.Since(start))
+	}
+
+	return err == nil
+}
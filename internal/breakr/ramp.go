@@ -0,0 +1,34 @@
+package breakr
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+const minRampShare = 0.01
+
+func (b *Breaker) rampShare() float64 {
+	progress := float64(time.Since(b.rampStart)) / float64(b.config.RampDuration)
+	if progress >= 1 {
+		return 1
+	}
+
+	if b.config.RampMode == config.RampExponential {
+		return math.Pow(minRampShare, 1-progress)
+	}
+	return math.Max(minRampShare, progress)
+}
+
+func (b *Breaker) startRamp(at time.Time) {
+	b.rampStart = at
+	atomic.StoreInt64(&b.rampEnd, at.Add(b.config.RampDuration).UnixNano())
+}
+
+func (b *Breaker) finishRamp() {
+	if b.state == Ramping && b.rampShare() >= 1 {
+		b.setState(Closed)
+	}
+}
@@ -0,0 +1,91 @@
+package breakr
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+func (b *Breaker) sharedWindow() config.SharedWindow {
+	window := b.config.WindowSize
+	if window == 0 {
+		window = b.config.ResetTimeout
+	}
+
+	return config.SharedWindow{
+		Threshold: float64(b.config.FailureThreshold),
+		Window:    window,
+		OpenFor:   b.config.ResetTimeout,
+	}
+}
+
+func (b *Breaker) checkShared() {
+	b.openFromShared(b.sharedOpenUntil())
+}
+
+func (b *Breaker) sharedOpenUntil() time.Time {
+	if b.config.SharedState == nil || b.throttle != nil {
+		return time.Time{}
+	}
+
+	until, err := b.config.SharedState.OpenUntil(b.config.Name)
+	if err != nil {
+		b.stateStoreError("shared")
+		return time.Time{}
+	}
+	return until
+}
+
+func (b *Breaker) openFromShared(until time.Time) {
+	if !time.Now().Before(until) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open {
+		return
+	}
+
+	b.trip(TripShared)
+	b.openedAt = until.Add(-b.config.ResetTimeout)
+}
+
+func (b *Breaker) shareFailure(before, after State, openedAt time.Time, weight float64) {
+	if b.config.SharedState == nil {
+		return
+	}
+
+	if after == Open {
+		if before != Open {
+			if err := b.config.SharedState.Open(b.config.Name, openedAt.Add(b.config.ResetTimeout)); err != nil {
+				b.stateStoreError("shared")
+			}
+		}
+		return
+	}
+
+	w := b.sharedWindow()
+	if w.Threshold <= 0 {
+		return
+	}
+
+	until, err := b.config.SharedState.RecordFailure(b.config.Name, weight, w)
+	if err != nil {
+		b.stateStoreError("shared")
+		return
+	}
+
+	b.openFromShared(until)
+}
+
+func (b *Breaker) shareSuccess(before, after State) {
+	if b.config.SharedState == nil || before != HalfOpen || after == HalfOpen || after == Open {
+		return
+	}
+
+	if err := b.config.SharedState.Close(b.config.Name); err != nil {
+		b.stateStoreError("shared")
+	}
+}
@@ -0,0 +1,138 @@
+package breakr
+
+import (
+	"sort"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+const sloBucketsPerShortWindow = 10
+
+type sloTracker struct {
+	target  float64
+	windows []config.BurnRateWindow
+	longest time.Duration
+	counts  *rollingWindow
+}
+
+func newSLOTracker(target float64, windows []config.BurnRateWindow) *sloTracker {
+	shortest, longest := windows[0].Short, windows[0].Long
+	for _, w := range windows {
+		if w.Short < shortest {
+			shortest = w.Short
+		}
+		if w.Long > longest {
+			longest = w.Long
+		}
+	}
+
+	bucketSize := shortest / sloBucketsPerShortWindow
+	if bucketSize <= 0 {
+		bucketSize = time.Millisecond
+	}
+	buckets := int(longest/bucketSize) + 1
+
+	return &sloTracker{
+		target:  target,
+		windows: windows,
+		longest: longest,
+		counts:  newRollingWindow(bucketSize*time.Duration(buckets), buckets),
+	}
+}
+
+func (s *sloTracker) record(now time.Time, bad bool) {
+	if bad {
+		s.counts.add(now, 1, 1)
+	} else {
+		s.counts.add(now, 1, 0)
+	}
+}
+
+func (s *sloTracker) burnRate(now time.Time, span time.Duration) (float64, float64) {
+	total, bad := s.counts.sum(now, span)
+	if total == 0 {
+		return 0, 0
+	}
+	return (bad / total) / (1 - s.target), bad
+}
+
+func (s *sloTracker) shouldTrip(now time.Time, minBad int) bool {
+	for _, w := range s.windows {
+		short, bad := s.burnRate(now, w.Short)
+		if bad < float64(minBad) || short < w.Threshold {
+			continue
+		}
+		if long, _ := s.burnRate(now, w.Long); long >= w.Threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sloTracker) budgetRemaining(now time.Time) float64 {
+	rate, _ := s.burnRate(now, s.longest)
+	return 1 - rate
+}
+
+func (s *sloTracker) reset() {
+	s.counts.reset()
+}
+
+func (s *sloTracker) snapshot(now time.Time) []SnapshotBucket {
+	cutoff := now.Add(-s.longest)
+	buckets := make([]SnapshotBucket, 0, len(s.counts.buckets))
+
+	for _, bucket := range s.counts.buckets {
+		start := time.Unix(0, bucket.epoch*int64(s.counts.bucketSize))
+		if bucket.total == 0 || !start.After(cutoff) {
+			continue
+		}
+
+		buckets = append(buckets, SnapshotBucket{
+			Start: start.UTC(),
+			Total: bucket.total,
+			Bad:   bucket.marked,
+		})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+
+	return buckets
+}
+
+func (s *sloTracker) restore(now time.Time, buckets []SnapshotBucket) {
+	s.counts.reset()
+
+	cutoff := now.Add(-s.longest)
+	for _, bucket := range buckets {
+		if bucket.Start.After(cutoff) && !bucket.Start.After(now) {
+			s.counts.add(bucket.Start, bucket.Total, bucket.Bad)
+		}
+	}
+}
+
+func (b *Breaker) recordSLO(bad bool) {
+	if b.slo == nil {
+		return
+	}
+
+	now := time.Now()
+	b.slo.record(now, bad)
+
+	if b.metrics != nil {
+		b.metrics.SetErrorBudgetRemaining(b.slo.budgetRemaining(now))
+	}
+}
+
+func (b *Breaker) ErrorBudgetRemaining() float64 {
+	if b.slo == nil {
+		return 1
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.slo.budgetRemaining(time.Now())
+}
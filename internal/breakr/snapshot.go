@@ -0,0 +1,153 @@
+package breakr
+
+import (
+	"fmt"
+	"time"
+)
+
+const snapshotVersion = 1
+
+type Snapshot struct {
+	Version     int               `json:"version"`
+	TakenAt     time.Time         `json:"taken_at"`
+	State       string            `json:"state"`
+	OpenUntil   *time.Time        `json:"open_until,omitempty"`
+	RampStart   *time.Time        `json:"ramp_start,omitempty"`
+	LastFailure *time.Time        `json:"last_failure,omitempty"`
+	TripReason  TripReason        `json:"trip_reason,omitempty"`
+	Calls       int               `json:"calls"`
+	Failures    []SnapshotFailure `json:"failures"`
+	BurnRate    []SnapshotBucket  `json:"burn_rate,omitempty"`
+}
+
+type SnapshotFailure struct {
+	Time     time.Time `json:"time"`
+	Weight   float64   `json:"weight"`
+	Category string    `json:"category"`
+}
+
+type SnapshotBucket struct {
+	Start time.Time `json:"start"`
+	Total float64   `json:"total"`
+	Bad   float64   `json:"bad"`
+}
+
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.finishRamp()
+	b.cleanUpFailures()
+
+	return b.snapshot()
+}
+
+func (b *Breaker) snapshot() Snapshot {
+	now := time.Now()
+	s := Snapshot{
+		Version:    snapshotVersion,
+		TakenAt:    now.UTC(),
+		State:      b.state.String(),
+		TripReason: b.tripReason,
+		Calls:      b.calls,
+		Failures:   make([]SnapshotFailure, 0, len(b.failures)),
+	}
+
+	switch b.state {
+	case Open:
+		s.OpenUntil = utcTime(b.openedAt.Add(b.config.ResetTimeout))
+	case Ramping:
+		s.RampStart = utcTime(b.rampStart)
+	}
+
+	if !b.lastFailureTime.IsZero() {
+		s.LastFailure = utcTime(b.lastFailureTime)
+	}
+
+	for _, f := range b.failures {
+		s.Failures = append(s.Failures, SnapshotFailure{
+			Time:     f.at.UTC(),
+			Weight:   f.weight,
+			Category: f.category.String(),
+		})
+	}
+
+	if b.slo != nil {
+		s.BurnRate = b.slo.snapshot(now)
+	}
+
+	return s
+}
+
+func (b *Breaker) Restore(s Snapshot) error {
+	if s.Version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, s.Version)
+	}
+
+	state, ok := parseState(s.State)
+	if !ok {
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidSnapshot, s.State)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	var cutoff time.Time
+	if b.config.WindowSize > 0 {
+		cutoff = now.Add(-b.config.WindowSize)
+	}
+
+	b.failures = make([]failure, 0, len(s.Failures))
+	for _, f := range s.Failures {
+		if !f.Time.After(cutoff) {
+			continue
+		}
+
+		b.failures = append(b.failures, failure{
+			at:       f.Time,
+			weight:   f.Weight,
+			category: parseCategory(f.Category),
+		})
+	}
+
+	b.calls = s.Calls
+	b.tripReason = s.TripReason
+	b.lastFailureTime = time.Time{}
+	if s.LastFailure != nil {
+		b.lastFailureTime = *s.LastFailure
+	}
+
+	if b.slo != nil {
+		b.slo.restore(now, s.BurnRate)
+	}
+
+	switch {
+	case state == Open && s.OpenUntil != nil && now.Before(*s.OpenUntil):
+		b.openedAt = s.OpenUntil.Add(-b.config.ResetTimeout)
+		b.setState(Open)
+		b.scheduleRecovery()
+	case state == Open:
+		b.setState(HalfOpen)
+	case state == Ramping && b.config.RampDuration > 0:
+		start := now
+		if s.RampStart != nil {
+			start = *s.RampStart
+		}
+		b.startRamp(start)
+		b.setState(Ramping)
+		b.finishRamp()
+	case state == Ramping:
+		b.setState(Closed)
+	default:
+		b.setState(state)
+	}
+
+	return nil
+}
+
+func utcTime(t time.Time) *time.Time {
+	t = t.UTC()
+	return &t
+}
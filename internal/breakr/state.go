@@ -1,11 +1,14 @@
 package breakr
 
+import "sync/atomic"
+
 type State int
 
 const (
 	Closed State = iota
 	Open
 	HalfOpen
+	Ramping
 )
 
 func (s State) String() string {
@@ -16,11 +19,22 @@ func (s State) String() string {
 		return "Open"
 	case HalfOpen:
 		return "Half-Open"
+	case Ramping:
+		return "Ramping"
 	default:
 		return "Unknown"
 	}
 }
 
+func parseState(s string) (State, bool) {
+	for _, state := range []State{Closed, Open, HalfOpen, Ramping} {
+		if state.String() == s {
+			return state, true
+		}
+	}
+	return Closed, false
+}
+
 func (b *Breaker) setState(to State) {
 	from := b.state
 	if from == to {
@@ -28,9 +42,16 @@ func (b *Breaker) setState(to State) {
 	}
 
 	b.state = to
+	atomic.StoreInt32(&b.current, int32(to))
+
+	if to == HalfOpen || to == Closed {
+		b.resetStrategy()
+	}
 
 	if b.config.Metrics != nil {
 		b.config.Metrics.Transition(from.String(), to.String())
 		b.config.Metrics.SetState(to.String())
 	}
+
+	b.persist()
 }
@@ -0,0 +1,60 @@
+package breakr
+
+import "time"
+
+type Stats struct {
+	State                State
+	Failures             int
+	FailureWeight        float64
+	Timeouts             float64
+	Errors               float64
+	SlowCalls            float64
+	TripReason           TripReason
+	LastFailure          time.Time
+	Calls                int
+	WarmingUp            bool
+	InFlight             int
+	Abandoned            int
+	RejectionProbability float64
+	ErrorBudgetRemaining float64
+}
+
+func (b *Breaker) Stats() Stats {
+	b.checkShared()
+
+	b.mu.Lock()
+	b.finishRamp()
+	b.cleanUpFailures()
+	counts := b.failureCounts()
+	stats := Stats{
+		State:         b.state,
+		FailureWeight: counts.failures(),
+		Timeouts:      counts.timeouts,
+		Errors:        counts.errors,
+		SlowCalls:     counts.slow,
+		LastFailure:   b.lastFailureTime,
+		Calls:         b.calls,
+		WarmingUp:     b.warmingUp(),
+		TripReason:    b.tripReason,
+	}
+	for _, f := range b.failures {
+		if f.category != categorySlow {
+			stats.Failures++
+		}
+	}
+	b.mu.Unlock()
+
+	stats.InFlight = b.InFlight()
+	stats.Abandoned = b.Abandoned()
+	stats.RejectionProbability = b.RejectionProbability()
+	stats.ErrorBudgetRemaining = b.ErrorBudgetRemaining()
+
+	return stats
+}
+
+func (b *Breaker) warmingUp() bool {
+	if b.config.WarmupCalls > 0 && b.calls < b.config.WarmupCalls {
+		return true
+	}
+	return b.config.WarmupDuration > 0 && time.Since(b.created) < b.config.WarmupDuration
+}
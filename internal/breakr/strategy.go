@@ -0,0 +1,23 @@
+package breakr
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+func (b *Breaker) recordOutcome(o config.Outcome) {
+	if b.config.TripStrategy != nil {
+		b.config.TripStrategy.Record(o)
+	}
+}
+
+func (b *Breaker) resetStrategy() {
+	if b.config.TripStrategy != nil {
+		b.config.TripStrategy.Reset()
+	}
+}
+
+func (b *Breaker) strategyAllowsClose() bool {
+	return b.config.TripStrategy == nil || b.config.TripStrategy.ShouldClose(time.Now())
+}
@@ -0,0 +1,69 @@
+package breakr
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+const (
+	defaultThrottleK      = 2
+	defaultThrottleWindow = 2 * time.Minute
+	throttleBuckets       = 60
+)
+
+type throttle struct {
+	mu      sync.Mutex
+	k       float64
+	span    time.Duration
+	window  *rollingWindow
+	metrics *metrics.Metrics
+}
+
+func newThrottle(k float64, span time.Duration, m *metrics.Metrics) *throttle {
+	if k == 0 {
+		k = defaultThrottleK
+	}
+	if span == 0 {
+		span = defaultThrottleWindow
+	}
+
+	return &throttle{
+		k:       k,
+		span:    span,
+		window:  newRollingWindow(span, throttleBuckets),
+		metrics: m,
+	}
+}
+
+func (t *throttle) allow() bool {
+	t.mu.Lock()
+	now := time.Now()
+	requests, accepts := t.window.sum(now, t.span)
+	t.window.add(now, 1, 0)
+	t.mu.Unlock()
+
+	p := math.Max(0, (requests-t.k*accepts)/(requests+1))
+	if t.metrics != nil {
+		t.metrics.SetRejectionProbability(p)
+	}
+
+	return rand.Float64() >= p
+}
+
+func (t *throttle) accept() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.window.add(time.Now(), 0, 1)
+}
+
+func (t *throttle) probability() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	requests, accepts := t.window.sum(time.Now(), t.span)
+	return math.Max(0, (requests-t.k*accepts)/(requests+1))
+}
@@ -0,0 +1,119 @@
+package breakr
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+type TripReason string
+
+const (
+	TripNone           TripReason = ""
+	TripFailures       TripReason = "failures"
+	TripTimeouts       TripReason = "timeouts"
+	TripErrors         TripReason = "errors"
+	TripSlowCalls      TripReason = "slow_calls"
+	TripBurnRate       TripReason = "burn_rate"
+	TripRecoveryFailed TripReason = "recovery_failed"
+	TripStrategy       TripReason = "strategy"
+	TripShared         TripReason = "shared"
+)
+
+type failureCategory int
+
+const (
+	categoryError failureCategory = iota
+	categoryTimeout
+	categorySlow
+)
+
+func (c failureCategory) String() string {
+	switch c {
+	case categoryTimeout:
+		return "timeout"
+	case categorySlow:
+		return "slow"
+	default:
+		return "error"
+	}
+}
+
+func parseCategory(s string) failureCategory {
+	switch s {
+	case categoryTimeout.String():
+		return categoryTimeout
+	case categorySlow.String():
+		return categorySlow
+	default:
+		return categoryError
+	}
+}
+
+func categorize(err error) failureCategory {
+	if failureClass(err) == config.FailureClassTimeout {
+		return categoryTimeout
+	}
+	return categoryError
+}
+
+type failureCounts struct {
+	timeouts float64
+	errors   float64
+	slow     float64
+}
+
+func (c failureCounts) failures() float64 {
+	return c.timeouts + c.errors
+}
+
+func (b *Breaker) evaluateTrip() bool {
+	if b.state == Open || b.warmingUp() {
+		return false
+	}
+
+	if reason := b.shouldTrip(); reason != TripNone {
+		b.trip(reason)
+		return true
+	}
+	return false
+}
+
+func (b *Breaker) trip(reason TripReason) {
+	b.tripReason = reason
+	b.openedAt = time.Now()
+	b.setState(Open)
+	b.scheduleRecovery()
+
+	if b.metrics != nil {
+		b.metrics.ObserveTrip(string(reason))
+	}
+}
+
+func (b *Breaker) failureCounts() failureCounts {
+	var cutoff time.Time
+	if b.config.WindowSize > 0 {
+		cutoff = time.Now().Add(-b.config.WindowSize)
+	}
+
+	var counts failureCounts
+	for _, f := range b.failures {
+		if !f.at.After(cutoff) {
+			continue
+		}
+
+		switch f.category {
+		case categoryTimeout:
+			counts.timeouts += f.weight
+		case categorySlow:
+			counts.slow += f.weight
+		default:
+			counts.errors += f.weight
+		}
+	}
+	return counts
+}
+
+func (b *Breaker) isSlow(d time.Duration) bool {
+	return b.config.SlowCallDuration > 0 && d >= b.config.SlowCallDuration
+}
@@ -0,0 +1,62 @@
+package breakr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+type failure struct {
+	at       time.Time
+	weight   float64
+	category failureCategory
+}
+
+func (b *Breaker) failureWeight(err error) float64 {
+	if b.config.FailureWeight != nil {
+		return b.config.FailureWeight(err)
+	}
+
+	if len(b.config.FailureWeights) == 0 {
+		return 1
+	}
+
+	var httpErr interface{ Code() int }
+	if errors.As(err, &httpErr) {
+		if w, ok := b.config.FailureWeights[strconv.Itoa(httpErr.Code())]; ok {
+			return w
+		}
+	}
+
+	if w, ok := b.config.FailureWeights[failureClass(err)]; ok {
+		return w
+	}
+
+	if w, ok := b.config.FailureWeights[config.FailureClassError]; ok {
+		return w
+	}
+
+	return 1
+}
+
+func failureClass(err error) string {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return config.FailureClassConnectionRefused
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return config.FailureClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return config.FailureClassTimeout
+	}
+
+	return config.FailureClassError
+}
@@ -0,0 +1,61 @@
+package breakr
+
+import "time"
+
+type rollingBucket struct {
+	epoch  int64
+	total  float64
+	marked float64
+}
+
+type rollingWindow struct {
+	bucketSize time.Duration
+	buckets    []rollingBucket
+}
+
+func newRollingWindow(size time.Duration, buckets int) *rollingWindow {
+	bucketSize := size / time.Duration(buckets)
+	if bucketSize <= 0 {
+		bucketSize = time.Millisecond
+	}
+
+	return &rollingWindow{
+		bucketSize: bucketSize,
+		buckets:    make([]rollingBucket, buckets),
+	}
+}
+
+func (w *rollingWindow) add(now time.Time, total, marked float64) {
+	epoch := now.UnixNano() / int64(w.bucketSize)
+	bucket := &w.buckets[epoch%int64(len(w.buckets))]
+
+	if bucket.epoch != epoch {
+		*bucket = rollingBucket{epoch: epoch}
+	}
+
+	bucket.total += total
+	bucket.marked += marked
+}
+
+func (w *rollingWindow) sum(now time.Time, span time.Duration) (total, marked float64) {
+	n := int64((span + w.bucketSize - 1) / w.bucketSize)
+	if n <= 0 || n > int64(len(w.buckets)) {
+		n = int64(len(w.buckets))
+	}
+
+	current := now.UnixNano() / int64(w.bucketSize)
+	for _, bucket := range w.buckets {
+		if bucket.epoch > current-n && bucket.epoch <= current {
+			total += bucket.total
+			marked += bucket.marked
+		}
+	}
+
+	return total, marked
+}
+
+func (w *rollingWindow) reset() {
+	for i := range w.buckets {
+		w.buckets[i] = rollingBucket{}
+	}
+}
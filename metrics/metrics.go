@@ -9,6 +9,27 @@ type Metrics struct {
 	duration      *prometheus.HistogramVec
 	stateGauge    *prometheus.GaugeVec
 	transitions   *prometheus.CounterVec
+	inFlight      prometheus.Gauge
+	attempts      *prometheus.CounterVec
+
+	concurrencyLimit    prometheus.Gauge
+	concurrencyInFlight prometheus.Gauge
+
+	rejectionProbability prometheus.Gauge
+
+	healthChecks        *prometheus.CounterVec
+	healthCheckDuration prometheus.Histogram
+
+	priorityRequests *prometheus.CounterVec
+	cacheRequests    *prometheus.CounterVec
+
+	abandoned       prometheus.Gauge
+	lateCompletions prometheus.Counter
+
+	errorBudget prometheus.Gauge
+	trips       *prometheus.CounterVec
+
+	stateStoreErrors *prometheus.CounterVec
 }
 
 func NewMetrics(subsystem string) *Metrics {
@@ -49,6 +70,125 @@ func NewMetrics(subsystem string) *Metrics {
 			},
 			[]string{"from", "to"},
 		),
+
+		inFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "in_flight",
+				Help:      "Number of calls currently admitted by the bulkhead",
+			},
+		),
+
+		attempts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "retry_attempts_total",
+				Help:      "Total number of retry attempts by attempt number and status",
+			},
+			[]string{"attempt", "status"},
+		),
+
+		concurrencyLimit: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "concurrency_limit",
+				Help:      "Current limit of the adaptive concurrency limiter",
+			},
+		),
+
+		concurrencyInFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "concurrency_in_flight",
+				Help:      "Number of calls currently admitted by the adaptive concurrency limiter",
+			},
+		),
+
+		rejectionProbability: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "rejection_probability",
+				Help:      "Current probability of rejecting a call in throttle mode",
+			},
+		),
+
+		healthChecks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "health_checks_total",
+				Help:      "Total number of health-check probes run while the circuit breaker is open",
+			},
+			[]string{"result"},
+		),
+
+		healthCheckDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Subsystem: subsystem,
+				Name:      "health_check_duration_seconds",
+				Help:      "Duration of health-check probes",
+				Buckets:   prometheus.DefBuckets,
+			},
+		),
+
+		priorityRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "priority_requests_total",
+				Help:      "Total number of requests by priority class and status",
+			},
+			[]string{"priority", "status"},
+		),
+
+		cacheRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "cache_requests_total",
+				Help:      "Total number of response cache lookups by result",
+			},
+			[]string{"result"},
+		),
+
+		abandoned: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "abandoned_in_flight",
+				Help:      "Number of abandoned executions that are still running",
+			},
+		),
+
+		lateCompletions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "late_completions_total",
+				Help:      "Total number of abandoned executions that completed after their caller gave up",
+			},
+		),
+
+		errorBudget: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Subsystem: subsystem,
+				Name:      "error_budget_remaining",
+				Help:      "Remaining share of the SLO error budget over the longest burn-rate window",
+			},
+		),
+
+		trips: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "trips_total",
+				Help:      "Total number of times the breaker opened, by reason",
+			},
+			[]string{"reason"},
+		),
+
+		stateStoreErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: subsystem,
+				Name:      "state_store_errors_total",
+				Help:      "Total number of failed state store operations, by op",
+			},
+			[]string{"op"},
+		),
 	}
 
 	prometheus.MustRegister(
@@ -56,6 +196,20 @@ func NewMetrics(subsystem string) *Metrics {
 		m.duration,
 		m.stateGauge,
 		m.transitions,
+		m.inFlight,
+		m.attempts,
+		m.concurrencyLimit,
+		m.concurrencyInFlight,
+		m.rejectionProbability,
+		m.healthChecks,
+		m.healthCheckDuration,
+		m.priorityRequests,
+		m.cacheRequests,
+		m.abandoned,
+		m.lateCompletions,
+		m.errorBudget,
+		m.trips,
+		m.stateStoreErrors,
 	)
 
 	return m
@@ -39,6 +39,83 @@ func newTestMetrics(t *testing.T) *Metrics {
 			},
 			[]string{"from", "to"},
 		),
+		inFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "in_flight",
+			},
+		),
+		attempts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retry_attempts_total",
+			},
+			[]string{"attempt", "status"},
+		),
+		concurrencyLimit: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "concurrency_limit",
+			},
+		),
+		concurrencyInFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "concurrency_in_flight",
+			},
+		),
+		rejectionProbability: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "rejection_probability",
+			},
+		),
+		healthChecks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "health_checks_total",
+			},
+			[]string{"result"},
+		),
+		healthCheckDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "health_check_duration_seconds",
+				Buckets: prometheus.DefBuckets,
+			},
+		),
+		priorityRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "priority_requests_total",
+			},
+			[]string{"priority", "status"},
+		),
+		cacheRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cache_requests_total",
+			},
+			[]string{"result"},
+		),
+		abandoned: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "abandoned_in_flight",
+			},
+		),
+		lateCompletions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "late_completions_total",
+			},
+		),
+		errorBudget: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "error_budget_remaining",
+			},
+		),
+		trips: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "trips_total",
+			},
+			[]string{"reason"},
+		),
+		stateStoreErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "state_store_errors_total",
+			},
+			[]string{"op"},
+		),
 	}
 
 	reg.MustRegister(
@@ -46,6 +123,20 @@ func newTestMetrics(t *testing.T) *Metrics {
 		m.duration,
 		m.stateGauge,
 		m.transitions,
+		m.inFlight,
+		m.attempts,
+		m.concurrencyLimit,
+		m.concurrencyInFlight,
+		m.rejectionProbability,
+		m.healthChecks,
+		m.healthCheckDuration,
+		m.priorityRequests,
+		m.cacheRequests,
+		m.abandoned,
+		m.lateCompletions,
+		m.errorBudget,
+		m.trips,
+		m.stateStoreErrors,
 	)
 
 	return m
@@ -75,6 +166,42 @@ func TestObserveError(t *testing.T) {
 	}
 }
 
+func TestObserveCancelled(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveCancelled("Closed", time.Millisecond)
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("cancelled", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected cancelled counter = 1, got %v", v)
+	}
+}
+
+func TestObserveBypassed(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveBypassed("Open", time.Millisecond)
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("bypassed", "Open"),
+	); v != 1 {
+		t.Fatalf("expected bypassed counter = 1, got %v", v)
+	}
+}
+
+func TestObserveDeadlineExceeded(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveDeadlineExceeded("Closed", time.Millisecond)
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("deadline_exceeded", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected deadline_exceeded counter = 1, got %v", v)
+	}
+}
+
 func TestObserveBlocked(t *testing.T) {
 	m := newTestMetrics(t)
 
@@ -134,3 +261,182 @@ func TestTransitionSameStateIgnored(t *testing.T) {
 		t.Fatalf("expected transition counter = 0, got %v", v)
 	}
 }
+
+func TestObserveBlockedByParent(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveBlockedByParent("Closed")
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("blocked_parent", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected blocked_parent counter = 1, got %v", v)
+	}
+}
+
+func TestObserveRejected(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveRejected("Closed")
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("rejected", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected rejected counter = 1, got %v", v)
+	}
+}
+
+func TestSetInFlight(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.SetInFlight(3)
+
+	if v := testutil.ToFloat64(m.inFlight); v != 3 {
+		t.Fatalf("expected in_flight gauge = 3, got %v", v)
+	}
+}
+
+func TestObserveRateLimited(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveRateLimited("Closed")
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("rate_limited", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected rate_limited counter = 1, got %v", v)
+	}
+}
+
+func TestObserveAttempt(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveAttempt(2, StatusError)
+
+	if v := testutil.ToFloat64(
+		m.attempts.WithLabelValues("2", "error"),
+	); v != 1 {
+		t.Fatalf("expected retry attempt counter = 1, got %v", v)
+	}
+}
+
+func TestSetConcurrency(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.SetConcurrencyLimit(20)
+	m.SetConcurrencyInFlight(4)
+
+	if v := testutil.ToFloat64(m.concurrencyLimit); v != 20 {
+		t.Fatalf("expected concurrency_limit gauge = 20, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.concurrencyInFlight); v != 4 {
+		t.Fatalf("expected concurrency_in_flight gauge = 4, got %v", v)
+	}
+}
+
+func TestObserveThrottled(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveThrottled("Closed")
+	m.SetRejectionProbability(0.25)
+
+	if v := testutil.ToFloat64(
+		m.requestsTotal.WithLabelValues("throttled", "Closed"),
+	); v != 1 {
+		t.Fatalf("expected throttled counter = 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.rejectionProbability); v != 0.25 {
+		t.Fatalf("expected rejection_probability gauge = 0.25, got %v", v)
+	}
+}
+
+func TestObserveHealthCheck(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveHealthCheck(true, time.Millisecond)
+	m.ObserveHealthCheck(false, time.Millisecond)
+	m.ObserveHealthCheck(false, time.Millisecond)
+
+	if v := testutil.ToFloat64(
+		m.healthChecks.WithLabelValues("success"),
+	); v != 1 {
+		t.Fatalf("expected successful health checks = 1, got %v", v)
+	}
+	if v := testutil.ToFloat64(
+		m.healthChecks.WithLabelValues("failure"),
+	); v != 2 {
+		t.Fatalf("expected failed health checks = 2, got %v", v)
+	}
+}
+
+func TestObservePriority(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObservePriority("critical", StatusSuccess)
+
+	if v := testutil.ToFloat64(
+		m.priorityRequests.WithLabelValues("critical", "success"),
+	); v != 1 {
+		t.Fatalf("expected priority counter = 1, got %v", v)
+	}
+}
+
+func TestObserveCache(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveCache(CacheStale)
+
+	if v := testutil.ToFloat64(
+		m.cacheRequests.WithLabelValues("stale"),
+	); v != 1 {
+		t.Fatalf("expected stale cache counter = 1, got %v", v)
+	}
+}
+
+func TestAbandoned(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.SetAbandoned(2)
+	m.ObserveLateCompletion()
+
+	if v := testutil.ToFloat64(m.abandoned); v != 2 {
+		t.Fatalf("expected abandoned_in_flight gauge = 2, got %v", v)
+	}
+	if v := testutil.ToFloat64(m.lateCompletions); v != 1 {
+		t.Fatalf("expected late_completions counter = 1, got %v", v)
+	}
+}
+
+func TestSetErrorBudgetRemaining(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.SetErrorBudgetRemaining(0.75)
+
+	if v := testutil.ToFloat64(m.errorBudget); v != 0.75 {
+		t.Fatalf("expected error_budget_remaining gauge = 0.75, got %v", v)
+	}
+}
+
+func TestObserveTrip(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveTrip("timeout")
+
+	if v := testutil.ToFloat64(
+		m.trips.WithLabelValues("timeout"),
+	); v != 1 {
+		t.Fatalf("expected trips counter = 1, got %v", v)
+	}
+}
+
+func TestObserveStateStoreError(t *testing.T) {
+	m := newTestMetrics(t)
+
+	m.ObserveStateStoreError("save")
+
+	if v := testutil.ToFloat64(
+		m.stateStoreErrors.WithLabelValues("save"),
+	); v != 1 {
+		t.Fatalf("expected state store errors counter = 1, got %v", v)
+	}
+}
@@ -1,6 +1,9 @@
 package metrics
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 func (m *Metrics) ObserveSuccess(state string, d time.Duration) {
 	m.requestsTotal.WithLabelValues(string(StatusSuccess), state).Inc()
@@ -17,11 +20,76 @@ func (m *Metrics) ObserveTimeout(state string, d time.Duration) {
 	m.duration.WithLabelValues(string(StatusTimeout)).Observe(d.Seconds())
 }
 
+func (m *Metrics) ObserveCancelled(state string, d time.Duration) {
+	m.requestsTotal.WithLabelValues(string(StatusCancelled), state).Inc()
+	m.duration.WithLabelValues(string(StatusCancelled)).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveBypassed(state string, d time.Duration) {
+	m.requestsTotal.WithLabelValues(string(StatusBypassed), state).Inc()
+	m.duration.WithLabelValues(string(StatusBypassed)).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveDeadlineExceeded(state string, d time.Duration) {
+	m.requestsTotal.WithLabelValues(string(StatusDeadlineExceeded), state).Inc()
+	m.duration.WithLabelValues(string(StatusDeadlineExceeded)).Observe(d.Seconds())
+}
+
 func (m *Metrics) ObserveBlocked(state string) {
 	m.requestsTotal.WithLabelValues(string(StatusBlocked), state).Inc()
 }
 
+func (m *Metrics) ObserveBlockedByParent(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusBlockedParent), state).Inc()
+}
+
+func (m *Metrics) ObserveRejected(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusRejected), state).Inc()
+}
+
+func (m *Metrics) ObserveRateLimited(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusRateLimited), state).Inc()
+}
+
+func (m *Metrics) ObserveLimitExceeded(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusLimitExceeded), state).Inc()
+}
+
+func (m *Metrics) ObserveThrottled(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusThrottled), state).Inc()
+}
+
+func (m *Metrics) ObserveAbandonedLimit(state string) {
+	m.requestsTotal.WithLabelValues(string(StatusAbandonedLimit), state).Inc()
+}
+
 func (m *Metrics) ObserveIgnored(state string, d time.Duration) {
 	m.requestsTotal.WithLabelValues(string(StatusIgnored), state).Inc()
 	m.duration.WithLabelValues(string(StatusIgnored)).Observe(d.Seconds())
 }
+
+func (m *Metrics) ObserveAttempt(attempt int, status Status) {
+	m.attempts.WithLabelValues(strconv.Itoa(attempt), string(status)).Inc()
+}
+
+func (m *Metrics) ObserveHealthCheck(success bool, d time.Duration) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+
+	m.healthChecks.WithLabelValues(result).Inc()
+	m.healthCheckDuration.Observe(d.Seconds())
+}
+
+func (m *Metrics) ObservePriority(priority string, status Status) {
+	m.priorityRequests.WithLabelValues(priority, string(status)).Inc()
+}
+
+func (m *Metrics) ObserveCache(result CacheResult) {
+	m.cacheRequests.WithLabelValues(string(result)).Inc()
+}
+
+func (m *Metrics) ObserveLateCompletion() {
+	m.lateCompletions.Inc()
+}
@@ -20,3 +20,71 @@ func (m *Metrics) Transition(from, to string) {
 		WithLabelValues(from, to).
 		Inc()
 }
+
+func (m *Metrics) ObserveTrip(reason string) {
+	if m == nil {
+		return
+	}
+
+	m.trips.
+		WithLabelValues(reason).
+		Inc()
+}
+
+func (m *Metrics) ObserveStateStoreError(op string) {
+	if m == nil {
+		return
+	}
+
+	m.stateStoreErrors.
+		WithLabelValues(op).
+		Inc()
+}
+
+func (m *Metrics) SetInFlight(n int) {
+	if m == nil {
+		return
+	}
+
+	m.inFlight.Set(float64(n))
+}
+
+func (m *Metrics) SetConcurrencyLimit(limit int) {
+	if m == nil {
+		return
+	}
+
+	m.concurrencyLimit.Set(float64(limit))
+}
+
+func (m *Metrics) SetConcurrencyInFlight(n int) {
+	if m == nil {
+		return
+	}
+
+	m.concurrencyInFlight.Set(float64(n))
+}
+
+func (m *Metrics) SetRejectionProbability(p float64) {
+	if m == nil {
+		return
+	}
+
+	m.rejectionProbability.Set(p)
+}
+
+func (m *Metrics) SetAbandoned(n int) {
+	if m == nil {
+		return
+	}
+
+	m.abandoned.Set(float64(n))
+}
+
+func (m *Metrics) SetErrorBudgetRemaining(v float64) {
+	if m == nil {
+		return
+	}
+
+	m.errorBudget.Set(v)
+}
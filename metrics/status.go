@@ -8,4 +8,25 @@ const (
 	StatusTimeout Status = "timeout"
 	StatusBlocked Status = "blocked"
 	StatusIgnored Status = "ignored_error"
+
+	StatusBlockedParent Status = "blocked_parent"
+	StatusRejected      Status = "rejected"
+	StatusRateLimited   Status = "rate_limited"
+	StatusCancelled     Status = "cancelled"
+	StatusLimitExceeded Status = "limit_exceeded"
+	StatusThrottled     Status = "throttled"
+	StatusBypassed      Status = "bypassed"
+
+	StatusDeadlineExceeded Status = "deadline_exceeded"
+	StatusAbandonedLimit   Status = "abandoned_limit"
+)
+
+const NoState = "None"
+
+type CacheResult string
+
+const (
+	CacheHit   CacheResult = "hit"
+	CacheMiss  CacheResult = "miss"
+	CacheStale CacheResult = "stale"
 )
@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genov8/breakr"
+	"github.com/genov8/breakr/bulkhead"
+	"github.com/genov8/breakr/cache"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/ratelimit"
+	"github.com/genov8/breakr/retry"
+)
+
+type Option func(*options)
+
+type options struct {
+	fallback func(ctx context.Context, err error) (interface{}, error)
+}
+
+func WithFallback(fn func(ctx context.Context, err error) (interface{}, error)) Option {
+	return func(o *options) {
+		o.fallback = fn
+	}
+}
+
+func FromConfig(cfg config.Config, opts ...Option) (*Pipeline, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	policies := make([]Policy, 0, len(cfg.Policies))
+	for _, spec := range cfg.Policies {
+		switch spec.Type {
+		case config.PolicyTimeout:
+			if spec.Timeout <= 0 {
+				return nil, fmt.Errorf("timeout policy: timeout must be > 0")
+			}
+			policies = append(policies, Timeout(spec.Timeout))
+
+		case config.PolicyRetry:
+			p := retry.Policy{
+				MaxAttempts:    spec.MaxAttempts,
+				InitialBackoff: spec.InitialBackoff,
+				MaxBackoff:     spec.MaxBackoff,
+				Multiplier:     spec.Multiplier,
+				Jitter:         spec.Jitter,
+				Metrics:        cfg.Metrics,
+			}
+			if err := p.Validate(); err != nil {
+				return nil, fmt.Errorf("retry policy: %w", err)
+			}
+			policies = append(policies, Retry(p))
+
+		case config.PolicyBreaker:
+			breakerCfg := cfg
+			breakerCfg.Policies = nil
+			breakerCfg.MaxConcurrent, breakerCfg.MaxQueue, breakerCfg.QueueTimeout = 0, 0, 0
+			breakerCfg.RateLimit, breakerCfg.RateBurst, breakerCfg.RateLimitWait = 0, 0, false
+			breakerCfg.CacheSize, breakerCfg.CacheTTL, breakerCfg.CacheFresh = 0, 0, 0
+			if err := breakerCfg.Validate(); err != nil {
+				return nil, fmt.Errorf("breaker policy: %w", err)
+			}
+			policies = append(policies, Wrap(breakr.New(breakerCfg)))
+
+		case config.PolicyBulkhead:
+			bh := bulkhead.Config{
+				MaxConcurrent: spec.MaxConcurrent,
+				MaxQueue:      spec.MaxQueue,
+				QueueTimeout:  spec.QueueTimeout,
+				Metrics:       cfg.Metrics,
+			}
+			if err := bh.Validate(); err != nil {
+				return nil, fmt.Errorf("bulkhead policy: %w", err)
+			}
+			policies = append(policies, Wrap(bulkhead.New(bh)))
+
+		case config.PolicyRateLimit:
+			rl := ratelimit.Config{
+				Rate:    spec.Rate,
+				Burst:   spec.Burst,
+				Wait:    spec.Wait,
+				Metrics: cfg.Metrics,
+			}
+			if err := rl.Validate(); err != nil {
+				return nil, fmt.Errorf("rate limit policy: %w", err)
+			}
+			policies = append(policies, Wrap(ratelimit.New(rl)))
+
+		case config.PolicyCache:
+			c := cache.Config{Size: spec.Size, TTL: spec.TTL}
+			if err := c.Validate(); err != nil {
+				return nil, fmt.Errorf("cache policy: %w", err)
+			}
+			policies = append(policies, Cache(CacheConfig{
+				Cache:        cache.New(c),
+				FailureCodes: cfg.FailureCodes,
+				Metrics:      cfg.Metrics,
+			}))
+
+		case config.PolicyFallback:
+			if o.fallback == nil {
+				return nil, fmt.Errorf("fallback policy: no fallback function provided")
+			}
+			policies = append(policies, Fallback(o.fallback))
+
+		default:
+			return nil, fmt.Errorf("unknown policy type %q", spec.Type)
+		}
+	}
+
+	return New(policies...), nil
+}
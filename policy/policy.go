@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/genov8/breakr"
+	"github.com/genov8/breakr/cache"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/metrics"
+	"github.com/genov8/breakr/retry"
+)
+
+type Func func(ctx context.Context) (interface{}, error)
+
+type Executor interface {
+	ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error)
+}
+
+type Policy interface {
+	Apply(ctx context.Context, next Func) (interface{}, error)
+}
+
+type PolicyFunc func(ctx context.Context, next Func) (interface{}, error)
+
+func (f PolicyFunc) Apply(ctx context.Context, next Func) (interface{}, error) {
+	return f(ctx, next)
+}
+
+type Pipeline struct {
+	policies []Policy
+}
+
+func New(policies ...Policy) *Pipeline {
+	return &Pipeline{policies: policies}
+}
+
+func (p *Pipeline) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	next := Func(fn)
+	for i := len(p.policies) - 1; i >= 0; i-- {
+		policy, inner := p.policies[i], next
+		next = func(ctx context.Context) (interface{}, error) {
+			return policy.Apply(ctx, inner)
+		}
+	}
+	return next(ctx)
+}
+
+func Wrap(e Executor) Policy {
+	return PolicyFunc(func(ctx context.Context, next Func) (interface{}, error) {
+		return e.ExecuteCtx(ctx, next)
+	})
+}
+
+func Timeout(d time.Duration) Policy {
+	return PolicyFunc(func(ctx context.Context, next Func) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return next(ctx)
+	})
+}
+
+func Retry(p retry.Policy) Policy {
+	return Wrap(retry.New(p, nil))
+}
+
+func Fallback(fn func(ctx context.Context, err error) (interface{}, error)) Policy {
+	return PolicyFunc(func(ctx context.Context, next Func) (interface{}, error) {
+		result, err := next(ctx)
+		if err != nil {
+			return fn(ctx, err)
+		}
+		return result, nil
+	})
+}
+
+type keyContext struct{}
+
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContext{}, key)
+}
+
+func Key(ctx context.Context) string {
+	key, _ := ctx.Value(keyContext{}).(string)
+	return key
+}
+
+type CacheConfig struct {
+	Cache        *cache.Cache
+	FailureCodes []int
+	Metrics      *metrics.Metrics
+}
+
+func Cache(cfg CacheConfig) Policy {
+	return PolicyFunc(func(ctx context.Context, next Func) (interface{}, error) {
+		key := Key(ctx)
+
+		result, err := next(ctx)
+		if key == "" {
+			return breakr.CachedResult{Value: result}, err
+		}
+
+		if err == nil {
+			cfg.Cache.Set(key, result)
+			cfg.observe(metrics.CacheMiss)
+			return breakr.CachedResult{Value: result, StoredAt: time.Now()}, nil
+		}
+
+		if cfg.servesStale(ctx, err) {
+			if value, storedAt, ok := cfg.Cache.Get(key); ok {
+				cfg.observe(metrics.CacheStale)
+				return breakr.CachedResult{Value: value, Stale: true, StoredAt: storedAt, Err: err}, nil
+			}
+		}
+
+		cfg.observe(metrics.CacheMiss)
+		return breakr.CachedResult{Err: err}, err
+	})
+}
+
+func (c CacheConfig) servesStale(ctx context.Context, err error) bool {
+	switch {
+	case ctx.Err() != nil:
+		return false
+	case errors.Is(err, breakr.ErrCircuitOpen), errors.Is(err, context.DeadlineExceeded):
+		return true
+	case errors.Is(err, breakr.ErrBulkheadFull), errors.Is(err, breakr.ErrRateLimited),
+		errors.Is(err, breakr.ErrLimitExceeded), errors.Is(err, breakr.ErrThrottled),
+		errors.Is(err, breakr.ErrTooManyAbandoned), errors.Is(err, context.Canceled):
+		return false
+	}
+	return config.IsFailure(err, c.FailureCodes)
+}
+
+func (c CacheConfig) observe(result metrics.CacheResult) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveCache(result)
+	}
+}
@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/genov8/breakr/metrics"
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+type Config struct {
+	Rate    float64
+	Burst   int
+	Wait    bool
+	Metrics *metrics.Metrics
+}
+
+func (c Config) Validate() error {
+	if c.Rate <= 0 {
+		return errors.New("Rate must be > 0")
+	}
+	if c.Burst < 0 {
+		return errors.New("Burst must be >= 0")
+	}
+	return nil
+}
+
+type Limiter struct {
+	mu     sync.Mutex
+	config Config
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func New(cfg Config) *Limiter {
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid rate limiter config: %v", err))
+	}
+
+	burst := float64(cfg.Burst)
+	if burst == 0 {
+		burst = 1
+	}
+
+	return &Limiter{
+		config: cfg,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+	return true
+}
+
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	delay := time.Duration((1 - l.tokens) / l.config.Rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+
+	l.tokens--
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l.config.Wait {
+		return l.Wait(ctx)
+	}
+	if !l.Allow() {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+func (l *Limiter) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if err := l.Acquire(ctx); err != nil {
+		if errors.Is(err, ErrRateLimited) && l.config.Metrics != nil {
+			l.config.Metrics.ObserveRateLimited(metrics.NoState)
+		}
+		return nil, err
+	}
+
+	return fn(ctx)
+}
+
+func (l *Limiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	l.tokens += elapsed * l.config.Rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
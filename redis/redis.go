@@ -0,0 +1,314 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/genov8/breakr/config"
+)
+
+var ErrUnavailable = errors.New("redis is unavailable")
+
+const windowBuckets = 10
+
+var recordScript = goredis.NewScript(`
+redis.replicate_commands()
+
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	return ttl
+end
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local size = tonumber(ARGV[1])
+local n = tonumber(ARGV[2])
+local epoch = math.floor(now / size)
+
+redis.call('HINCRBYFLOAT', KEYS[2], epoch, ARGV[3])
+redis.call('PEXPIRE', KEYS[2], size * (n + 1))
+
+local total = 0
+local buckets = redis.call('HGETALL', KEYS[2])
+for i = 1, #buckets, 2 do
+	if tonumber(buckets[i]) <= epoch - n then
+		redis.call('HDEL', KEYS[2], buckets[i])
+	else
+		total = total + tonumber(buckets[i + 1])
+	end
+end
+
+if total >= tonumber(ARGV[4]) then
+	redis.call('SET', KEYS[1], 'Open', 'PX', ARGV[5])
+	redis.call('PUBLISH', ARGV[6], 'open:' .. ARGV[5] .. ':' .. ARGV[7])
+	return tonumber(ARGV[5])
+end
+
+return 0
+`)
+
+var openScript = goredis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl >= tonumber(ARGV[1]) then
+	return ttl
+end
+
+redis.call('SET', KEYS[1], 'Open', 'PX', ARGV[1])
+redis.call('PUBLISH', ARGV[2], 'open:' .. ARGV[1] .. ':' .. ARGV[3])
+return tonumber(ARGV[1])
+`)
+
+var closeScript = goredis.NewScript(`
+redis.call('DEL', KEYS[1], KEYS[2])
+redis.call('PUBLISH', ARGV[1], 'closed:0:' .. ARGV[2])
+return 0
+`)
+
+type Config struct {
+	Client        goredis.UniversalClient
+	Prefix        string
+	Timeout       time.Duration
+	RefreshPeriod time.Duration
+	RetryAfter    time.Duration
+}
+
+type entry struct {
+	openUntil  time.Time
+	checked    time.Time
+	refreshing bool
+}
+
+type Shared struct {
+	config Config
+	pubsub *goredis.PubSub
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	downAt  time.Time
+}
+
+func New(cfg Config) *Shared {
+	if cfg.Client == nil {
+		panic("invalid redis config: Client must be set")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "breakr"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 100 * time.Millisecond
+	}
+	if cfg.RefreshPeriod <= 0 {
+		cfg.RefreshPeriod = time.Second
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+
+	s := &Shared{
+		config:  cfg,
+		entries: make(map[string]*entry),
+	}
+
+	s.pubsub = cfg.Client.Subscribe(context.Background(), s.channel())
+	go s.listen(s.pubsub.Channel())
+
+	return s
+}
+
+func (s *Shared) OpenUntil(name string) (time.Time, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	e := s.entry(name)
+	if e.refreshing || now.Sub(e.checked) < s.config.RefreshPeriod {
+		until := e.openUntil
+		s.mu.Unlock()
+		return until, nil
+	}
+	e.refreshing = true
+	s.mu.Unlock()
+
+	var ttl time.Duration
+	err := s.do(func(ctx context.Context) error {
+		var err error
+		ttl, err = s.config.Client.PTTL(ctx, s.stateKey(name)).Result()
+		return err
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.refreshing = false
+	e.checked = now
+	if err != nil {
+		return e.openUntil, err
+	}
+
+	e.openUntil = openUntil(now, ttl)
+	return e.openUntil, nil
+}
+
+func (s *Shared) RecordFailure(name string, weight float64, w config.SharedWindow) (time.Time, error) {
+	size := bucketSize(w.Window)
+
+	now := time.Now()
+	var ttl int64
+	err := s.do(func(ctx context.Context) error {
+		var err error
+		ttl, err = recordScript.Run(ctx, s.config.Client,
+			[]string{s.stateKey(name), s.windowKey(name)},
+			size.Milliseconds(), windowBuckets,
+			strconv.FormatFloat(weight, 'f', -1, 64), strconv.FormatFloat(w.Threshold, 'f', -1, 64),
+			w.OpenFor.Milliseconds(), s.channel(), name,
+		).Int64()
+		return err
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return s.update(name, now, time.Duration(ttl)*time.Millisecond), nil
+}
+
+func (s *Shared) Open(name string, until time.Time) error {
+	now := time.Now()
+	d := until.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+
+	var ttl int64
+	err := s.do(func(ctx context.Context) error {
+		var err error
+		ttl, err = openScript.Run(ctx, s.config.Client,
+			[]string{s.stateKey(name)},
+			d.Milliseconds(), s.channel(), name,
+		).Int64()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.update(name, now, time.Duration(ttl)*time.Millisecond)
+	return nil
+}
+
+func (s *Shared) Close(name string) error {
+	now := time.Now()
+	err := s.do(func(ctx context.Context) error {
+		return closeScript.Run(ctx, s.config.Client,
+			[]string{s.stateKey(name), s.windowKey(name)},
+			s.channel(), name,
+		).Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	s.update(name, now, 0)
+	return nil
+}
+
+func (s *Shared) Shutdown() error {
+	return s.pubsub.Close()
+}
+
+func (s *Shared) do(fn func(ctx context.Context) error) error {
+	s.mu.Lock()
+	down := !s.downAt.IsZero() && time.Since(s.downAt) < s.config.RetryAfter
+	s.mu.Unlock()
+
+	if down {
+		return ErrUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	err := fn(ctx)
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		s.mu.Lock()
+		s.downAt = time.Now()
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	s.mu.Lock()
+	s.downAt = time.Time{}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Shared) listen(messages <-chan *goredis.Message) {
+	for msg := range messages {
+		parts := strings.SplitN(msg.Payload, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		ms, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ttl := time.Duration(ms) * time.Millisecond
+		if parts[0] == "closed" {
+			ttl = 0
+		}
+		s.update(parts[2], time.Now(), ttl)
+	}
+}
+
+func (s *Shared) update(name string, now time.Time, ttl time.Duration) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(name)
+	e.openUntil = openUntil(now, ttl)
+	e.checked = now
+	return e.openUntil
+}
+
+func (s *Shared) entry(name string) *entry {
+	e, ok := s.entries[name]
+	if !ok {
+		e = &entry{}
+		s.entries[name] = e
+	}
+	return e
+}
+
+func (s *Shared) stateKey(name string) string {
+	return s.config.Prefix + ":{" + name + "}:state"
+}
+
+func (s *Shared) windowKey(name string) string {
+	return s.config.Prefix + ":{" + name + "}:failures"
+}
+
+func (s *Shared) channel() string {
+	return s.config.Prefix + ":transitions"
+}
+
+func bucketSize(window time.Duration) time.Duration {
+	size := window / windowBuckets
+	if size < time.Millisecond {
+		size = time.Millisecond
+	}
+	return size
+}
+
+func openUntil(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
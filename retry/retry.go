@@ -0,0 +1,150 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/metrics"
+)
+
+type Executor interface {
+	ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error)
+}
+
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	Retryable      func(err error) bool
+	OnRetry        func(attempt int, err error, delay time.Duration)
+	Metrics        *metrics.Metrics
+}
+
+func (p Policy) Validate() error {
+	if p.MaxAttempts <= 0 {
+		return errors.New("MaxAttempts must be > 0")
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("InitialBackoff and MaxBackoff must be >= 0")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return errors.New("Multiplier must be >= 1")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.New("Jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+type Retrier struct {
+	policy   Policy
+	executor Executor
+}
+
+func New(p Policy, exec Executor) *Retrier {
+	if err := p.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid retry policy: %v", err))
+	}
+
+	return &Retrier{policy: p, executor: exec}
+}
+
+func Attempt(ctx context.Context) int {
+	return breakr.AttemptFrom(ctx)
+}
+
+func (r *Retrier) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	var lastErr error
+
+	for attempt := 1; attempt <= r.policy.MaxAttempts; attempt++ {
+		attemptCtx := breakr.WithAttempt(ctx, attempt)
+
+		var result interface{}
+		var err error
+		if r.executor != nil {
+			result, err = r.executor.ExecuteCtx(attemptCtx, fn)
+		} else {
+			result, err = fn(attemptCtx)
+		}
+
+		r.observe(attempt, err)
+
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if attempt == r.policy.MaxAttempts || !r.retryable(ctx, err) {
+			break
+		}
+
+		delay := r.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+
+		if r.policy.OnRetry != nil {
+			r.policy.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, lastErr
+		}
+	}
+
+	return nil, lastErr
+}
+
+func (r *Retrier) retryable(ctx context.Context, err error) bool {
+	if errors.Is(err, breakr.ErrCircuitOpen) || ctx.Err() != nil {
+		return false
+	}
+	if r.policy.Retryable != nil {
+		return r.policy.Retryable(err)
+	}
+	return true
+}
+
+func (r *Retrier) backoff(attempt int) time.Duration {
+	multiplier := r.policy.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	delay := float64(r.policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if r.policy.MaxBackoff > 0 && delay > float64(r.policy.MaxBackoff) {
+		delay = float64(r.policy.MaxBackoff)
+	}
+
+	if r.policy.Jitter > 0 {
+		delay -= delay * r.policy.Jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+func (r *Retrier) observe(attempt int, err error) {
+	if r.policy.Metrics == nil {
+		return
+	}
+
+	switch {
+	case err == nil:
+		r.policy.Metrics.ObserveAttempt(attempt, metrics.StatusSuccess)
+	case errors.Is(err, breakr.ErrCircuitOpen):
+		r.policy.Metrics.ObserveAttempt(attempt, metrics.StatusBlocked)
+	default:
+		r.policy.Metrics.ObserveAttempt(attempt, metrics.StatusError)
+	}
+}
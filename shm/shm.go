@@ -0,0 +1,316 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package shm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/genov8/breakr/config"
+)
+
+const (
+	segmentSize = 4096
+
+	readyMagic   uint64 = 0x6272656b72000001
+	initializing uint64 = 1 << 63
+
+	offsetInit      = 0
+	offsetOpenUntil = 8
+	offsetBuckets   = 64
+
+	ringSize      = 16
+	windowBuckets = 10
+	weightScale   = 1000
+)
+
+var ErrInitTimeout = errors.New("timed out waiting for shared memory initialization")
+
+var ErrClosed = errors.New("shared memory state is shut down")
+
+type segment struct {
+	file *os.File
+	data []byte
+}
+
+type Shared struct {
+	readers int64
+
+	dir      string
+	mu       sync.Mutex
+	segments sync.Map
+	closed   atomic.Bool
+}
+
+func New(dir string) (*Shared, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create shared memory directory: %w", err)
+	}
+	return &Shared{dir: dir}, nil
+}
+
+func (s *Shared) OpenUntil(name string) (time.Time, error) {
+	var until time.Time
+	err := s.use(name, func(seg *segment) {
+		until = unixNano(atomic.LoadInt64(seg.openUntil()))
+	})
+	return until, err
+}
+
+func (s *Shared) RecordFailure(name string, weight float64, w config.SharedWindow) (time.Time, error) {
+	var until time.Time
+	err := s.use(name, func(seg *segment) {
+		now := time.Now()
+		if current := atomic.LoadInt64(seg.openUntil()); current > now.UnixNano() {
+			until = unixNano(current)
+			return
+		}
+
+		size := bucketSize(w.Window)
+		epoch := uint32(now.UnixNano() / int64(size))
+		seg.add(epoch, uint32(weight*weightScale))
+
+		if float64(seg.sum(epoch))/weightScale >= w.Threshold {
+			until = unixNano(seg.extend(now.Add(w.OpenFor).UnixNano()))
+		}
+	})
+	return until, err
+}
+
+func (s *Shared) Open(name string, until time.Time) error {
+	return s.use(name, func(seg *segment) {
+		seg.extend(until.UnixNano())
+	})
+}
+
+func (s *Shared) Close(name string) error {
+	return s.use(name, func(seg *segment) {
+		for {
+			until := atomic.LoadInt64(seg.openUntil())
+			if until > time.Now().UnixNano() {
+				return
+			}
+			if atomic.CompareAndSwapInt64(seg.openUntil(), until, 0) {
+				break
+			}
+		}
+
+		for i := 0; i < ringSize; i++ {
+			atomic.StoreUint64(seg.bucket(i), 0)
+		}
+	})
+}
+
+func (s *Shared) Shutdown() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	for atomic.LoadInt64(&s.readers) > 0 {
+		runtime.Gosched()
+	}
+
+	var errs []error
+	s.segments.Range(func(name, v interface{}) bool {
+		seg := v.(*segment)
+		errs = append(errs, syscall.Munmap(seg.data), seg.file.Close())
+		s.segments.Delete(name)
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+func (s *Shared) use(name string, fn func(seg *segment)) error {
+	atomic.AddInt64(&s.readers, 1)
+	defer atomic.AddInt64(&s.readers, -1)
+
+	if s.closed.Load() {
+		return ErrClosed
+	}
+
+	v, ok := s.segments.Load(name)
+	if !ok {
+		seg, err := s.open(name)
+		if err != nil {
+			return err
+		}
+		v = seg
+	}
+
+	fn(v.(*segment))
+	return nil
+}
+
+func (s *Shared) open(name string) (*segment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.segments.Load(name); ok {
+		return v.(*segment), nil
+	}
+
+	seg, err := openSegment(filepath.Join(s.dir, url.PathEscape(name)+".shm"))
+	if err != nil {
+		return nil, err
+	}
+
+	s.segments.Store(name, seg)
+	return seg, nil
+}
+
+func openSegment(path string) (*segment, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	if info.Size() < segmentSize {
+		if err := f.Truncate(segmentSize); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, segmentSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	seg := &segment{file: f, data: data}
+	if err := seg.init(); err != nil {
+		_ = syscall.Munmap(data)
+		_ = f.Close()
+		return nil, err
+	}
+
+	return seg, nil
+}
+
+func (seg *segment) init() error {
+	owner := initializing | uint64(os.Getpid())
+	deadline := time.Now().Add(time.Second)
+
+	for {
+		state := atomic.LoadUint64(seg.word(offsetInit))
+
+		switch {
+		case state == readyMagic:
+			return nil
+		case state&initializing != 0 && processAlive(int(state&^initializing)):
+			if time.Now().After(deadline) {
+				return ErrInitTimeout
+			}
+			time.Sleep(time.Millisecond)
+			continue
+		}
+
+		if atomic.CompareAndSwapUint64(seg.word(offsetInit), state, owner) {
+			atomic.StoreInt64(seg.openUntil(), 0)
+			for i := 0; i < ringSize; i++ {
+				atomic.StoreUint64(seg.bucket(i), 0)
+			}
+			atomic.StoreUint64(seg.word(offsetInit), readyMagic)
+			return nil
+		}
+	}
+}
+
+func (seg *segment) word(offset int) *uint64 {
+	return (*uint64)(unsafe.Pointer(&seg.data[offset]))
+}
+
+func (seg *segment) openUntil() *int64 {
+	return (*int64)(unsafe.Pointer(&seg.data[offsetOpenUntil]))
+}
+
+func (seg *segment) bucket(i int) *uint64 {
+	return seg.word(offsetBuckets + i*8)
+}
+
+func (seg *segment) add(epoch uint32, weight uint32) {
+	b := seg.bucket(int(epoch % ringSize))
+
+	for {
+		old := atomic.LoadUint64(b)
+
+		next := uint64(epoch)<<32 | uint64(weight)
+		if uint32(old>>32) == epoch {
+			count := old&0xffffffff + uint64(weight)
+			if count > 0xffffffff {
+				count = 0xffffffff
+			}
+			next = old&^0xffffffff | count
+		}
+
+		if atomic.CompareAndSwapUint64(b, old, next) {
+			return
+		}
+	}
+}
+
+func (seg *segment) sum(epoch uint32) uint64 {
+	var total uint64
+	for i := 0; i < ringSize; i++ {
+		v := atomic.LoadUint64(seg.bucket(i))
+		if v == 0 {
+			continue
+		}
+
+		if age := epoch - uint32(v>>32); age < windowBuckets {
+			total += v & 0xffffffff
+		}
+	}
+	return total
+}
+
+func (seg *segment) extend(until int64) int64 {
+	for {
+		current := atomic.LoadInt64(seg.openUntil())
+		if current >= until {
+			return current
+		}
+		if atomic.CompareAndSwapInt64(seg.openUntil(), current, until) {
+			return until
+		}
+	}
+}
+
+func processAlive(pid int) bool {
+	if pid == os.Getpid() {
+		return true
+	}
+
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+func bucketSize(window time.Duration) time.Duration {
+	size := window / windowBuckets
+	if size < time.Millisecond {
+		size = time.Millisecond
+	}
+	return size
+}
+
+func unixNano(ns int64) time.Time {
+	if ns <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
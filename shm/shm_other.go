@@ -0,0 +1,38 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package shm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+var ErrUnsupported = errors.New("shared memory state is not supported on this platform")
+
+type Shared struct{}
+
+func New(string) (*Shared, error) {
+	return nil, ErrUnsupported
+}
+
+func (*Shared) OpenUntil(string) (time.Time, error) {
+	return time.Time{}, ErrUnsupported
+}
+
+func (*Shared) RecordFailure(string, float64, config.SharedWindow) (time.Time, error) {
+	return time.Time{}, ErrUnsupported
+}
+
+func (*Shared) Open(string, time.Time) error {
+	return ErrUnsupported
+}
+
+func (*Shared) Close(string) error {
+	return ErrUnsupported
+}
+
+func (*Shared) Shutdown() error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/genov8/breakr/config"
+)
+
+type File struct {
+	dir string
+}
+
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create state directory: %w", err)
+	}
+	return &File{dir: dir}, nil
+}
+
+func (f *File) Load(name string) ([]byte, error) {
+	path, err := f.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := f.lock(name, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, config.ErrStateNotFound
+	}
+	return data, err
+}
+
+func (f *File) Save(name string, data []byte) error {
+	path, err := f.path(name)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := f.lock(name, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tmp, err := os.CreateTemp(f.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *File) lock(name string, exclusive bool) (func(), error) {
+	lockFile, err := os.OpenFile(filepath.Join(f.dir, url.PathEscape(name)+".lock"), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFD(lockFile, exclusive); err != nil {
+		_ = lockFile.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = unlockFD(lockFile)
+		_ = lockFile.Close()
+	}, nil
+}
+
+func (f *File) path(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("state name must not be empty")
+	}
+	return filepath.Join(f.dir, url.PathEscape(name)+".json"), nil
+}
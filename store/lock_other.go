@@ -0,0 +1,13 @@
+//go:build !unix
+
+package store
+
+import "os"
+
+func lockFD(*os.File, bool) error {
+	return nil
+}
+
+func unlockFD(*os.File) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/genov8/breakr/config"
+)
+
+type Memory struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{states: make(map[string][]byte)}
+}
+
+func (m *Memory) Load(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.states[name]
+	if !ok {
+		return nil, config.ErrStateNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (m *Memory) Save(name string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.states[name] = append([]byte(nil), data...)
+	return nil
+}
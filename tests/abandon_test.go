@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestAbandonedExecutions(t *testing.T) {
+	var mu sync.Mutex
+	var discarded []interface{}
+
+	cb := breakr.New(config.Config{
+		FailureThreshold: 10,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		MaxAbandoned:     2,
+		OnDiscard: func(result interface{}, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			discarded = append(discarded, result)
+		},
+	})
+
+	release := make(chan struct{})
+	stuckFn := func() (interface{}, error) {
+		<-release
+		return "late", nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cb.Execute(stuckFn); !errors.Is(err, breakr.ErrExecutionTimeout) {
+			t.Fatalf("expected execution timeout, got %v", err)
+		}
+	}
+
+	if n := cb.Abandoned(); n != 2 {
+		t.Errorf("expected 2 abandoned executions, got %d", n)
+	}
+
+	_, err := cb.Execute(func() (interface{}, error) {
+		return "ok", nil
+	})
+	if !errors.Is(err, breakr.ErrTooManyAbandoned) {
+		t.Errorf("expected new calls to be rejected above MaxAbandoned, got %v", err)
+	}
+
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	if n := cb.Abandoned(); n != 0 {
+		t.Errorf("expected abandoned executions to finish, got %d", n)
+	}
+
+	mu.Lock()
+	if len(discarded) != 2 || discarded[0] != "late" {
+		t.Errorf("expected late results to be passed to OnDiscard, got %v", discarded)
+	}
+	mu.Unlock()
+
+	if _, err := cb.Execute(func() (interface{}, error) {
+		return "ok", nil
+	}); err != nil {
+		t.Errorf("expected calls to be admitted again, got %v", err)
+	}
+}
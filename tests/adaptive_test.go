@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/adaptive"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestAdaptiveLimiterRejectsAboveLimit(t *testing.T) {
+	l := adaptive.New(adaptive.Config{
+		Algorithm:    &adaptive.AIMD{},
+		InitialLimit: 2,
+	})
+
+	first, err := l.Acquire()
+	if err != nil {
+		t.Fatalf("expected acquire to succeed, got %v", err)
+	}
+	second, err := l.Acquire()
+	if err != nil {
+		t.Fatalf("expected acquire to succeed, got %v", err)
+	}
+
+	if _, err := l.Acquire(); !errors.Is(err, adaptive.ErrLimitExceeded) {
+		t.Errorf("expected limit exceeded error, got %v", err)
+	}
+
+	first.Ignore()
+	second.Ignore()
+
+	if l.InFlight() != 0 {
+		t.Errorf("expected no calls in flight, got %d", l.InFlight())
+	}
+}
+
+func TestAdaptiveAlgorithms(t *testing.T) {
+	algorithms := map[string]adaptive.Algorithm{
+		"aimd":     &adaptive.AIMD{},
+		"vegas":    &adaptive.Vegas{},
+		"gradient": &adaptive.Gradient{},
+	}
+
+	for name, algorithm := range algorithms {
+		t.Run(name, func(t *testing.T) {
+			l := adaptive.New(adaptive.Config{
+				Algorithm:    algorithm,
+				InitialLimit: 10,
+				MinLimit:     2,
+				MaxLimit:     50,
+			})
+
+			for i := 0; i < 20; i++ {
+				token, err := l.Acquire()
+				if err != nil {
+					t.Fatalf("expected acquire to succeed, got %v", err)
+				}
+				token.Dropped()
+			}
+
+			if l.Limit() >= 10 {
+				t.Errorf("expected drops to lower the limit, got %d", l.Limit())
+			}
+			if l.Limit() < 2 {
+				t.Errorf("expected the limit to stay above MinLimit, got %d", l.Limit())
+			}
+		})
+	}
+}
+
+func TestAdaptiveAIMDIncreasesUnderLoad(t *testing.T) {
+	l := adaptive.New(adaptive.Config{
+		Algorithm:    &adaptive.AIMD{},
+		InitialLimit: 4,
+		MaxLimit:     6,
+	})
+
+	for i := 0; i < 10; i++ {
+		tokens := make([]*adaptive.Token, 0, l.Limit())
+		for j := 0; j < l.Limit(); j++ {
+			token, err := l.Acquire()
+			if err != nil {
+				t.Fatalf("expected acquire to succeed, got %v", err)
+			}
+			tokens = append(tokens, token)
+		}
+		for _, token := range tokens {
+			token.Success()
+		}
+	}
+
+	if l.Limit() != 6 {
+		t.Errorf("expected the limit to grow up to MaxLimit, got %d", l.Limit())
+	}
+}
+
+func TestCircuitBreakerAdaptiveLimit(t *testing.T) {
+	l := adaptive.New(adaptive.Config{
+		Algorithm:    &adaptive.AIMD{},
+		InitialLimit: 1,
+	})
+
+	cb := breakr.New(config.Config{
+		FailureThreshold:   2,
+		ResetTimeout:       time.Second,
+		ExecutionTimeout:   500 * time.Millisecond,
+		ConcurrencyLimiter: l,
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	go func() {
+		_, _ = cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return "ok", nil
+		})
+	}()
+
+	<-started
+
+	_, err := cb.Execute(func() (interface{}, error) {
+		return "ok", nil
+	})
+	if !errors.Is(err, adaptive.ErrLimitExceeded) {
+		t.Errorf("expected limit exceeded error, got %v", err)
+	}
+
+	close(release)
+}
+
+func TestCircuitBreakerAdaptiveIgnoresIgnoredErrors(t *testing.T) {
+	l := adaptive.New(adaptive.Config{
+		Algorithm:    &adaptive.AIMD{},
+		InitialLimit: 10,
+		MinLimit:     2,
+		MaxLimit:     50,
+	})
+
+	cb := breakr.New(config.Config{
+		FailureThreshold:   5,
+		ResetTimeout:       time.Second,
+		ExecutionTimeout:   500 * time.Millisecond,
+		FailureCodes:       []int{500},
+		ConcurrencyLimiter: l,
+	})
+
+	for i := 0; i < 20; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) {
+			return nil, &httpError{code: 404, msg: "not found"}
+		})
+	}
+
+	if l.Limit() != 10 {
+		t.Errorf("expected ignored errors to leave the limit at 10, got %d", l.Limit())
+	}
+}
@@ -223,3 +223,94 @@ func TestCircuitBreakerWithContext(t *testing.T) {
 		t.Errorf("expected context deadline exceeded, got: %v", err)
 	}
 }
+
+func TestCircuitBreakerParent(t *testing.T) {
+	parent := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	child := parent.NewChild(config.Config{
+		FailureThreshold:  5,
+		ResetTimeout:      time.Second,
+		ExecutionTimeout:  500 * time.Millisecond,
+		PropagateToParent: true,
+	})
+
+	sibling := parent.NewChild(config.Config{
+		FailureThreshold: 5,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	successFn := func() (interface{}, error) {
+		return "success", nil
+	}
+
+	_, _ = child.Execute(failFn)
+	_, _ = child.Execute(failFn)
+
+	if parent.State() != breakr.Open {
+		t.Errorf("expected parent to be Open, got %v", parent.State())
+	}
+
+	if child.State() != breakr.Closed {
+		t.Errorf("expected child to stay Closed, got %v", child.State())
+	}
+
+	_, err := sibling.Execute(successFn)
+	if !errors.Is(err, breakr.ErrParentOpen) {
+		t.Errorf("expected parent open error, got %v", err)
+	}
+
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected parent open error to match ErrCircuitOpen, got %v", err)
+	}
+
+	if !sibling.ParentOpen() {
+		t.Errorf("expected sibling to report an open parent")
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	_, err = child.Execute(successFn)
+	if err != nil {
+		t.Errorf("expected success, got error: %v", err)
+	}
+
+	if parent.State() != breakr.Closed {
+		t.Errorf("expected parent to be Closed, got %v", parent.State())
+	}
+}
+
+func TestLateSuccessKeepsBreakerOpen(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cb.Execute(func() (interface{}, error) {
+			time.Sleep(100 * time.Millisecond)
+			return "ok", nil
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	<-done
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected a success admitted before the trip to leave the breaker Open, got %v", cb.State())
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/bulkhead"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestBulkheadRejectsWhenFull(t *testing.T) {
+	bh := bulkhead.New(bulkhead.Config{MaxConcurrent: 2})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = bh.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+				started <- struct{}{}
+				<-release
+				return "ok", nil
+			})
+		}()
+	}
+
+	<-started
+	<-started
+
+	if bh.InFlight() != 2 {
+		t.Errorf("expected 2 calls in flight, got %d", bh.InFlight())
+	}
+
+	_, err := bh.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return "ok", nil
+	})
+	if !errors.Is(err, bulkhead.ErrBulkheadFull) {
+		t.Errorf("expected bulkhead full error, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if bh.InFlight() != 0 {
+		t.Errorf("expected no calls in flight, got %d", bh.InFlight())
+	}
+}
+
+func TestBulkheadQueue(t *testing.T) {
+	bh := bulkhead.New(bulkhead.Config{
+		MaxConcurrent: 1,
+		MaxQueue:      1,
+		QueueTimeout:  100 * time.Millisecond,
+	})
+
+	if err := bh.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected first acquire to succeed, got %v", err)
+	}
+
+	start := time.Now()
+	if err := bh.Acquire(context.Background()); !errors.Is(err, bulkhead.ErrBulkheadFull) {
+		t.Errorf("expected queue timeout to reject, got %v", err)
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Errorf("expected call to wait in the queue for QueueTimeout")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		bh.Release()
+	}()
+
+	if err := bh.Acquire(context.Background()); err != nil {
+		t.Errorf("expected queued call to be admitted, got %v", err)
+	}
+	bh.Release()
+}
+
+func TestCircuitBreakerBulkhead(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		MaxConcurrent:    1,
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	go func() {
+		_, _ = cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return "ok", nil
+		})
+	}()
+
+	<-started
+
+	for i := 0; i < 3; i++ {
+		_, err := cb.Execute(func() (interface{}, error) {
+			return "ok", nil
+		})
+		if !errors.Is(err, bulkhead.ErrBulkheadFull) {
+			t.Errorf("expected bulkhead full error, got %v", err)
+		}
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected rejections not to trip the breaker, got %v", cb.State())
+	}
+
+	close(release)
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/cache"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestCacheEviction(t *testing.T) {
+	c := cache.New(cache.Config{Size: 2, TTL: 100 * time.Millisecond})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	if _, _, ok := c.Get("b"); ok {
+		t.Errorf("expected the least recently used entry to be evicted")
+	}
+	if v, _, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("expected entry a to be kept, got %v", v)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if _, _, ok := c.Get("a"); ok {
+		t.Errorf("expected entry a to expire after TTL")
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected expired entries to be removed on lookup, got %d entries", c.Len())
+	}
+}
+
+func TestExecuteCachedFallback(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		CacheSize:        10,
+		CacheTTL:         time.Minute,
+	})
+
+	result, err := cb.ExecuteCached(context.Background(), "user:1", func(ctx context.Context) (interface{}, error) {
+		return "alice", nil
+	})
+	if err != nil || result.Value != "alice" || result.Stale {
+		t.Fatalf("expected a fresh result, got %+v, %v", result, err)
+	}
+
+	failFn := func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	result, err = cb.ExecuteCached(context.Background(), "user:1", failFn)
+	if err != nil || result.Value != "alice" || !result.Stale {
+		t.Errorf("expected a stale result on failure, got %+v, %v", result, err)
+	}
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected Open, got %v", cb.State())
+	}
+
+	result, _ = cb.ExecuteCached(context.Background(), "user:1", failFn)
+	if !result.Stale || !errors.Is(result.Err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected a stale result while Open, got %+v", result)
+	}
+
+	_, err = cb.ExecuteCached(context.Background(), "user:2", failFn)
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected a cache miss to return the error, got %v", err)
+	}
+}
+
+func TestExecuteCachedSkipsIgnoredErrors(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 5,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		FailureCodes:     []int{500},
+		CacheSize:        10,
+		CacheTTL:         time.Minute,
+	})
+
+	_, _ = cb.ExecuteCached(context.Background(), "user:1", func(ctx context.Context) (interface{}, error) {
+		return "alice", nil
+	})
+
+	notFound := &httpError{code: 404, msg: "not found"}
+	result, err := cb.ExecuteCached(context.Background(), "user:1", func(ctx context.Context) (interface{}, error) {
+		return nil, notFound
+	})
+	if !errors.Is(err, notFound) || result.Stale {
+		t.Errorf("expected an ignored error to bypass the cache, got %+v, %v", result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err = cb.ExecuteCached(ctx, "user:1", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) || result.Stale {
+		t.Errorf("expected caller cancellation to bypass the cache, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestTimeoutThreshold(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 100,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		TimeoutThreshold: 2,
+		ErrorThreshold:   4,
+		SlowThreshold:    2,
+		SlowCallDuration: 10 * time.Millisecond,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}
+
+	stats := cb.Stats()
+	if stats.State != breakr.Open {
+		t.Fatalf("expected 2 timeouts to open the breaker, got %v", stats.State)
+	}
+	if stats.TripReason != breakr.TripTimeouts {
+		t.Errorf("expected trip reason %q, got %q", breakr.TripTimeouts, stats.TripReason)
+	}
+	if stats.Timeouts != 2 || stats.Errors != 3 {
+		t.Errorf("expected 2 timeouts and 3 errors, got %+v", stats)
+	}
+}
+
+func TestErrorThreshold(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 100,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		TimeoutThreshold: 2,
+		ErrorThreshold:   4,
+		SlowThreshold:    2,
+		SlowCallDuration: 10 * time.Millisecond,
+	})
+
+	for i := 0; i < 4; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) {
+			return nil, &httpError{code: 500, msg: "internal error"}
+		})
+	}
+
+	stats := cb.Stats()
+	if stats.State != breakr.Open || stats.TripReason != breakr.TripErrors {
+		t.Fatalf("expected the breaker to open on errors, got %+v", stats)
+	}
+}
+
+func TestSlowCallThreshold(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 100,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		TimeoutThreshold: 2,
+		ErrorThreshold:   4,
+		SlowThreshold:    2,
+		SlowCallDuration: 10 * time.Millisecond,
+	})
+
+	slowFn := func() (interface{}, error) {
+		time.Sleep(15 * time.Millisecond)
+		return "ok", nil
+	}
+
+	result, err := cb.Execute(slowFn)
+	if err != nil || result != "ok" {
+		t.Fatalf("expected a slow call to still succeed, got %v, %v", result, err)
+	}
+	if stats := cb.Stats(); stats.SlowCalls != 1 || stats.Failures != 0 {
+		t.Fatalf("expected 1 slow call and no failures, got %+v", stats)
+	}
+
+	_, _ = cb.Execute(slowFn)
+
+	stats := cb.Stats()
+	if stats.State != breakr.Open || stats.TripReason != breakr.TripSlowCalls {
+		t.Fatalf("expected the breaker to open on slow calls, got %+v", stats)
+	}
+}
+
+func TestFastSuccessClearsCategories(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 100,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		TimeoutThreshold: 2,
+		ErrorThreshold:   4,
+		SlowThreshold:    2,
+		SlowCallDuration: 10 * time.Millisecond,
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		time.Sleep(15 * time.Millisecond)
+		return "ok", nil
+	})
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+
+	if stats := cb.Stats(); stats.SlowCalls != 0 || stats.Errors != 0 {
+		t.Errorf("expected a fast success to clear the counts, got %+v", stats)
+	}
+}
+
+func TestTripReasonRecoveryFailed(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     50 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+
+	_, _ = cb.Execute(failFn)
+	if reason := cb.Stats().TripReason; reason != breakr.TripFailures {
+		t.Fatalf("expected trip reason %q, got %q", breakr.TripFailures, reason)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	_, _ = cb.Execute(failFn)
+
+	if reason := cb.Stats().TripReason; reason != breakr.TripRecoveryFailed {
+		t.Errorf("expected trip reason %q, got %q", breakr.TripRecoveryFailed, reason)
+	}
+}
+
+func TestCategoriesWithoutThresholdUseFailureThreshold(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 4,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		TimeoutThreshold: 2,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected errors below FailureThreshold to keep the breaker closed, got %v", cb.State())
+	}
+
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+
+	stats := cb.Stats()
+	if stats.State != breakr.Open || stats.TripReason != breakr.TripFailures {
+		t.Errorf("expected errors without an ErrorThreshold to trip on FailureThreshold, got %+v", stats)
+	}
+}
+
+func TestLateFailureKeepsTripReason(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 100,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 50 * time.Millisecond,
+		TimeoutThreshold: 1,
+		ErrorThreshold:   2,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	<-done
+
+	if stats := cb.Stats(); stats.State != breakr.Open || stats.TripReason != breakr.TripErrors {
+		t.Errorf("expected a late timeout not to trip the open breaker again, got %+v", stats)
+	}
+}
@@ -51,3 +51,31 @@ func TestLoadConfigJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadConfigJSONFailureWeights(t *testing.T) {
+	jsonData := `{
+		"failure_threshold": 5,
+		"reset_timeout": "30s",
+		"failure_weights": {"500": 1, "timeout": 3}
+	}`
+
+	tmpFile, err := os.CreateTemp("", "config-*.json")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write([]byte(jsonData)); err != nil {
+		t.Fatalf("Error writing to temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	conf, err := config.LoadConfigJSON(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+
+	if conf.FailureWeights["500"] != 1 || conf.FailureWeights[config.FailureClassTimeout] != 3 {
+		t.Errorf("Expected weights 500=1 and timeout=3, got %v", conf.FailureWeights)
+	}
+}
@@ -54,3 +54,143 @@ failure_codes:
 		}
 	}
 }
+
+func TestLoadConfigYAMLPolicies(t *testing.T) {
+	yamlData := `
+failure_threshold: 2
+reset_timeout: "3s"
+execution_timeout: "1s"
+policies:
+  - type: timeout
+    timeout: "2s"
+  - type: rate_limit
+    rate: 50
+    burst: 5
+    wait: true
+  - type: breaker
+`
+
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write([]byte(yamlData)); err != nil {
+		t.Fatalf("Error writing to temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	conf, err := config.LoadConfigYAML(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+
+	if len(conf.Policies) != 3 {
+		t.Fatalf("Expected 3 policies, got %d", len(conf.Policies))
+	}
+
+	if conf.Policies[0].Type != config.PolicyTimeout || conf.Policies[0].Timeout != 2*time.Second {
+		t.Errorf("Expected timeout policy of 2s, got %+v", conf.Policies[0])
+	}
+
+	rl := conf.Policies[1]
+	if rl.Type != config.PolicyRateLimit || rl.Rate != 50 || rl.Burst != 5 || !rl.Wait {
+		t.Errorf("Expected rate limit policy 50/s burst 5 with wait, got %+v", rl)
+	}
+
+	if conf.Policies[2].Type != config.PolicyBreaker {
+		t.Errorf("Expected breaker policy, got %+v", conf.Policies[2])
+	}
+}
+
+func TestLoadConfigYAMLBurnRateWindows(t *testing.T) {
+	yamlData := `
+failure_threshold: 5
+reset_timeout: 30s
+slo_target: 0.999
+burn_rate_windows:
+  - short: 5m
+    long: 1h
+    threshold: 14.4
+  - short: 30m
+    long: 6h
+    threshold: 6
+`
+
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write([]byte(yamlData)); err != nil {
+		t.Fatalf("Error writing to temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	conf, err := config.LoadConfigYAML(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+
+	if conf.SLOTarget != 0.999 {
+		t.Errorf("Expected SLOTarget 0.999, got %v", conf.SLOTarget)
+	}
+
+	expected := []config.BurnRateWindow{
+		{Short: 5 * time.Minute, Long: time.Hour, Threshold: 14.4},
+		{Short: 30 * time.Minute, Long: 6 * time.Hour, Threshold: 6},
+	}
+	if len(conf.BurnRateWindows) != len(expected) {
+		t.Fatalf("Expected %d burn-rate windows, got %d", len(expected), len(conf.BurnRateWindows))
+	}
+	for i, w := range expected {
+		if conf.BurnRateWindows[i] != w {
+			t.Errorf("Expected burn-rate window %+v, got %+v", w, conf.BurnRateWindows[i])
+		}
+	}
+}
+
+func TestLoadConfigYAMLFailureWeights(t *testing.T) {
+	yamlData := `
+failure_threshold: 5
+reset_timeout: 30s
+failure_weights:
+  500: 1
+  503: 0.5
+  timeout: 3
+  connection_refused: 3
+`
+
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write([]byte(yamlData)); err != nil {
+		t.Fatalf("Error writing to temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	conf, err := config.LoadConfigYAML(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+
+	expected := map[string]float64{
+		"500":                                1,
+		"503":                                0.5,
+		config.FailureClassTimeout:           3,
+		config.FailureClassConnectionRefused: 3,
+	}
+	if len(conf.FailureWeights) != len(expected) {
+		t.Fatalf("Expected %d failure weights, got %v", len(expected), conf.FailureWeights)
+	}
+	for key, w := range expected {
+		if conf.FailureWeights[key] != w {
+			t.Errorf("Expected weight %v for %q, got %v", w, key, conf.FailureWeights[key])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/gossip"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func startGossipCluster(t *testing.T, ids []string, quorum int) map[string]*gossip.Node {
+	t.Helper()
+
+	listeners := make(map[string]net.Listener, len(ids))
+	for _, id := range ids {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		listeners[id] = l
+	}
+
+	nodes := make(map[string]*gossip.Node, len(ids))
+	for _, id := range ids {
+		peers := make(map[string]string, len(ids)-1)
+		for _, peer := range ids {
+			if peer != id {
+				peers[peer] = "http://" + listeners[peer].Addr().String()
+			}
+		}
+
+		node := gossip.New(gossip.Config{
+			ID:       id,
+			Peers:    peers,
+			Quorum:   quorum,
+			Interval: 20 * time.Millisecond,
+		})
+		nodes[id] = node
+
+		server := &http.Server{Handler: node.Handler()}
+		go func(l net.Listener) { _ = server.Serve(l) }(listeners[id])
+
+		node.Start()
+		t.Cleanup(func() {
+			node.Stop()
+			_ = server.Close()
+		})
+	}
+
+	return nodes
+}
+
+func TestGossipQuorum(t *testing.T) {
+	nodes := startGossipCluster(t, []string{"a", "b", "c"}, 2)
+
+	breakers := make(map[string]*breakr.Breaker, len(nodes))
+	for id, node := range nodes {
+		breakers[id] = breakr.New(config.Config{
+			Name:             "payments",
+			FailureThreshold: 2,
+			ResetTimeout:     time.Minute,
+			ExecutionTimeout: 500 * time.Millisecond,
+			SharedState:      node,
+		})
+	}
+	a, b, c := breakers["a"], breakers["b"], breakers["c"]
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 2; i++ {
+		_, _ = a.Execute(failFn)
+	}
+	if a.State() != breakr.Open {
+		t.Fatalf("expected a to open, got %v", a.State())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if _, err := c.Execute(successFn); err != nil || c.State() != breakr.Closed {
+		t.Fatalf("expected c to stay closed with one open peer, got %v, %v", c.State(), err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = b.Execute(failFn)
+	}
+
+	waitForState(t, c, successFn, breakr.Open)
+	if reason := c.Stats().TripReason; reason != breakr.TripShared {
+		t.Errorf("expected trip reason %q, got %q", breakr.TripShared, reason)
+	}
+}
+
+func TestGossipMergeIsIdempotent(t *testing.T) {
+	node := gossip.New(gossip.Config{ID: "local", Peers: map[string]string{"remote": "http://remote"}, Quorum: 1})
+	server := httptest.NewServer(node.Handler())
+	defer server.Close()
+
+	post := func(body string) {
+		t.Helper()
+		if status := postGossip(t, server.URL, body); status != http.StatusOK {
+			t.Fatalf("unexpected status %d", status)
+		}
+	}
+
+	open := `{"from":"remote","states":{"payments":{"remote":{"version":3,"open":true,"open_for_ms":60000}}}}`
+	post(open)
+
+	first, _ := node.OpenUntil("payments")
+	if until := time.Until(first); until < 59*time.Second || until > time.Minute {
+		t.Fatalf("expected the open period to be relative to the local clock, got %v", until)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	post(open)
+	post(`{"from":"remote","states":{"payments":{"remote":{"version":2,"open":false}}}}`)
+
+	second, _ := node.OpenUntil("payments")
+	if !second.Equal(first) {
+		t.Errorf("expected replayed and older records to be ignored, got %v then %v", first, second)
+	}
+
+	post(`{"from":"remote","states":{"payments":{"remote":{"version":4,"open":false}}}}`)
+	if until, _ := node.OpenUntil("payments"); !until.IsZero() {
+		t.Errorf("expected a newer closed record to win, got %v", until)
+	}
+}
+
+func TestGossipIgnoresUnknownNodes(t *testing.T) {
+	node := gossip.New(gossip.Config{ID: "local", Peers: map[string]string{"remote": "http://remote"}, Quorum: 1})
+	server := httptest.NewServer(node.Handler())
+	defer server.Close()
+
+	status := postGossip(t, server.URL, `{"from":"intruder","states":{"payments":{"intruder":{"version":1,"open":true,"open_for_ms":60000}}}}`)
+	if status != http.StatusForbidden {
+		t.Errorf("expected a message from an unknown peer to be rejected, got status %d", status)
+	}
+
+	status = postGossip(t, server.URL, `{"from":"remote","states":{"payments":{"intruder":{"version":1,"open":true,"open_for_ms":60000}}}}`)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	if until, _ := node.OpenUntil("payments"); !until.IsZero() {
+		t.Errorf("expected records of unknown nodes not to count towards the quorum, got %v", until)
+	}
+}
+
+func postGossip(t *testing.T, url, body string) int {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to post gossip: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestGossipWindowSummaries(t *testing.T) {
+	nodes := startGossipCluster(t, []string{"a", "b", "c"}, 2)
+
+	breakers := make(map[string]*breakr.Breaker, len(nodes))
+	for id, node := range nodes {
+		breakers[id] = breakr.New(config.Config{
+			Name:             "payments",
+			FailureThreshold: 3,
+			ResetTimeout:     time.Minute,
+			ExecutionTimeout: 500 * time.Millisecond,
+			WindowSize:       time.Minute,
+			SharedState:      node,
+		})
+	}
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	_, _ = breakers["a"].Execute(failFn)
+	_, _ = breakers["b"].Execute(failFn)
+	time.Sleep(100 * time.Millisecond)
+
+	_, _ = breakers["c"].Execute(failFn)
+	if stats := breakers["c"].Stats(); stats.State != breakr.Open || stats.TripReason != breakr.TripShared {
+		t.Fatalf("expected the combined window to open c, got %+v", stats)
+	}
+
+	waitForState(t, breakers["a"], successFn, breakr.Open)
+	waitForState(t, breakers["b"], successFn, breakr.Open)
+}
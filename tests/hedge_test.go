@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestHedgedRequest(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: time.Second,
+		HedgeDelay:       50 * time.Millisecond,
+	})
+
+	var calls int32
+	fn := func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-time.After(500 * time.Millisecond):
+				return "slow", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return "fast", nil
+	}
+
+	start := time.Now()
+	result, err := cb.ExecuteHedged(context.Background(), fn)
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if result != "fast" {
+		t.Errorf("expected the hedge to win, got %v", result)
+	}
+
+	if time.Since(start) > 300*time.Millisecond {
+		t.Errorf("expected hedged call to finish early, took %v", time.Since(start))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected the cancelled loser not to count as a failure, got %v", cb.State())
+	}
+}
+
+func TestHedgedRequestRefusedWhenNotClosed(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: time.Second,
+		HedgeDelay:       20 * time.Millisecond,
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	time.Sleep(150 * time.Millisecond)
+
+	if cb.State() != breakr.HalfOpen {
+		t.Fatalf("expected Half-Open, got %v", cb.State())
+	}
+
+	var calls int32
+	_, err := cb.ExecuteHedged(context.Background(), func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond)
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected no hedges while Half-Open, got %d calls", n)
+	}
+}
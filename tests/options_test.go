@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/metrics"
+)
+
+func TestExecuteWithTimeoutOverride(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 50 * time.Millisecond,
+	})
+
+	slowFn := func(ctx context.Context) (interface{}, error) {
+		time.Sleep(100 * time.Millisecond)
+		return "report", nil
+	}
+
+	result, err := cb.ExecuteWith(context.Background(), slowFn, breakr.WithTimeout(time.Second))
+	if err != nil || result != "report" {
+		t.Errorf("expected the overridden timeout to allow the slow call, got %v, %v", result, err)
+	}
+
+	_, err = cb.ExecuteWith(context.Background(), slowFn)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the default timeout without options, got %v", err)
+	}
+}
+
+func TestExecuteWithBypass(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected Open, got %v", cb.State())
+	}
+
+	result, err := cb.ExecuteWith(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return "healthy", nil
+	}, breakr.WithBypass())
+	if err != nil || result != "healthy" {
+		t.Errorf("expected bypass to run the call while Open, got %v, %v", result, err)
+	}
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected bypassed calls not to change the state, got %v", cb.State())
+	}
+}
+
+func TestExecuteWithEvents(t *testing.T) {
+	var mu sync.Mutex
+	var events []config.Event
+
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		OnEvent: func(e config.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		},
+	})
+
+	_, _ = cb.ExecuteWith(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return "ok", nil
+	},
+		breakr.WithPriority(config.PriorityLow),
+		breakr.WithKey("nightly-export"),
+		breakr.WithLabel("team", "billing"),
+	)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	e := events[0]
+	if e.Status != metrics.StatusSuccess || e.State != "Closed" {
+		t.Errorf("expected a success event in Closed, got %s in %s", e.Status, e.State)
+	}
+	if e.Priority != config.PriorityLow || e.Key != "nightly-export" || e.Labels["team"] != "billing" {
+		t.Errorf("expected call options in the event, got %+v", e)
+	}
+}
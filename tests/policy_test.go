@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr"
+	"github.com/genov8/breakr/cache"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/policy"
+	"github.com/genov8/breakr/retry"
+)
+
+func TestPolicyPipeline(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	var seen []error
+	p := policy.New(
+		policy.Fallback(func(ctx context.Context, err error) (interface{}, error) {
+			seen = append(seen, err)
+			return "fallback", nil
+		}),
+		policy.Retry(retry.Policy{MaxAttempts: 5, InitialBackoff: time.Millisecond}),
+		policy.Wrap(cb),
+	)
+
+	calls := 0
+	result, err := p.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, errors.New("error")
+	})
+
+	if err != nil || result != "fallback" {
+		t.Fatalf("expected the fallback result, got %v, %v", result, err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected retries to stop once the breaker opened, got %d calls", calls)
+	}
+
+	if len(seen) != 1 || !errors.Is(seen[0], breakr.ErrCircuitOpen) {
+		t.Errorf("expected the fallback to see the inner error, got %v", seen)
+	}
+}
+
+func TestPolicyPipelineFromConfig(t *testing.T) {
+	jsonData := `{
+		"failure_threshold": 3,
+		"reset_timeout": "1s",
+		"execution_timeout": "500ms",
+		"policies": [
+			{"type": "cache", "size": 10, "ttl": "1m"},
+			{"type": "timeout", "timeout": "200ms"},
+			{"type": "retry", "max_attempts": 2, "initial_backoff": "1ms"},
+			{"type": "breaker"},
+			{"type": "bulkhead", "max_concurrent": 4}
+		]
+	}`
+
+	tmpFile, err := os.CreateTemp("", "config-*.json")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+
+	if _, err := tmpFile.Write([]byte(jsonData)); err != nil {
+		t.Fatalf("Error writing to temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	conf, err := config.LoadConfigJSON(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+
+	if len(conf.Policies) != 5 || conf.Policies[2].MaxAttempts != 2 {
+		t.Fatalf("expected 5 policies, got %+v", conf.Policies)
+	}
+
+	p, err := policy.FromConfig(*conf)
+	if err != nil {
+		t.Fatalf("expected a pipeline, got error: %v", err)
+	}
+
+	ctx := policy.WithKey(context.Background(), "item:1")
+
+	result, err := p.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+		return "value", nil
+	})
+	if cached, ok := result.(breakr.CachedResult); err != nil || !ok || cached.Value != "value" || cached.Stale {
+		t.Fatalf("expected a fresh result, got %+v, %v", result, err)
+	}
+
+	calls := 0
+	result, err = p.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, errors.New("error")
+	})
+	if cached, ok := result.(breakr.CachedResult); err != nil || !ok || cached.Value != "value" || !cached.Stale {
+		t.Errorf("expected a stale cached value on failure, got %+v, %v", result, err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+
+	if _, err := policy.FromConfig(config.Config{
+		Policies: []config.PolicySpec{{Type: "unknown"}},
+	}); err == nil {
+		t.Errorf("expected an error for an unknown policy type")
+	}
+
+	if _, err := policy.FromConfig(config.Config{
+		Policies: []config.PolicySpec{{Type: config.PolicyTimeout}},
+	}); err == nil {
+		t.Errorf("expected an error for a timeout policy without a timeout")
+	}
+}
+
+func TestCachePolicySkipsCancelledAndRejectedCalls(t *testing.T) {
+	c := cache.New(cache.Config{Size: 10, TTL: time.Minute})
+	p := policy.New(policy.Cache(policy.CacheConfig{Cache: c, FailureCodes: []int{500}}))
+
+	ctx := policy.WithKey(context.Background(), "item:1")
+	_, _ = p.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+		return "value", nil
+	})
+
+	errs := []error{breakr.ErrBulkheadFull, breakr.ErrRateLimited, &httpError{code: 404, msg: "not found"}}
+	for _, want := range errs {
+		_, err := p.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+			return nil, want
+		})
+		if !errors.Is(err, want) {
+			t.Errorf("expected %v to bypass the cache, got %v", want, err)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := p.ExecuteCtx(cancelled, func(ctx context.Context) (interface{}, error) {
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected caller cancellation to bypass the cache, got %v", err)
+	}
+
+	result, err := p.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, breakr.ErrCircuitOpen
+	})
+	if cached, _ := result.(breakr.CachedResult); err != nil || !cached.Stale || !errors.Is(cached.Err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected a stale value while the breaker is open, got %+v, %v", result, err)
+	}
+}
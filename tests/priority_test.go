@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/bulkhead"
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestPriorityAdmissionHalfOpen(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		AdmissionShares: map[config.ShareScope]map[config.Priority]float64{
+			config.ShareHalfOpen: {
+				config.PriorityLow:    0,
+				config.PriorityNormal: 0,
+				config.PriorityHigh:   0,
+			},
+		},
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	time.Sleep(150 * time.Millisecond)
+
+	successFn := func(ctx context.Context) (interface{}, error) {
+		return "success", nil
+	}
+
+	_, err := cb.ExecuteWith(context.Background(), successFn, breakr.WithPriority(config.PriorityLow))
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected low priority to be rejected in Half-Open, got %v", err)
+	}
+
+	_, err = cb.ExecuteWith(context.Background(), successFn)
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected normal priority to be rejected in Half-Open, got %v", err)
+	}
+
+	if cb.State() != breakr.HalfOpen {
+		t.Fatalf("expected Half-Open, got %v", cb.State())
+	}
+
+	_, err = cb.ExecuteWith(context.Background(), successFn, breakr.WithPriority(config.PriorityCritical))
+	if err != nil {
+		t.Errorf("expected critical priority to pass in Half-Open, got %v", err)
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected Closed, got %v", cb.State())
+	}
+}
+
+func TestPriorityBulkheadShedding(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		MaxConcurrent:    2,
+		AdmissionShares: map[config.ShareScope]map[config.Priority]float64{
+			config.ShareBulkhead: {
+				config.PriorityLow: 0.5,
+			},
+		},
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	go func() {
+		_, _ = cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return "ok", nil
+		})
+	}()
+
+	<-started
+
+	fastFn := func(ctx context.Context) (interface{}, error) {
+		return "ok", nil
+	}
+
+	_, err := cb.ExecuteWith(context.Background(), fastFn, breakr.WithPriority(config.PriorityLow))
+	if !errors.Is(err, bulkhead.ErrBulkheadFull) {
+		t.Errorf("expected low priority to be shed under bulkhead pressure, got %v", err)
+	}
+
+	_, err = cb.ExecuteWith(context.Background(), fastFn, breakr.WithPriority(config.PriorityHigh))
+	if err != nil {
+		t.Errorf("expected high priority to use the remaining slot, got %v", err)
+	}
+
+	close(release)
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestHealthCheckProbes(t *testing.T) {
+	var healthy atomic.Bool
+	var probes int32
+
+	cb := breakr.New(config.Config{
+		FailureThreshold:    1,
+		ResetTimeout:        50 * time.Millisecond,
+		ExecutionTimeout:    500 * time.Millisecond,
+		HealthCheckInterval: 50 * time.Millisecond,
+		HealthCheck: func(ctx context.Context) error {
+			atomic.AddInt32(&probes, 1)
+			if !healthy.Load() {
+				return errors.New("unhealthy")
+			}
+			return nil
+		},
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	time.Sleep(200 * time.Millisecond)
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected the breaker to stay Open while probes fail, got %v", cb.State())
+	}
+
+	_, err := cb.Execute(func() (interface{}, error) {
+		return "success", nil
+	})
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected live traffic to be rejected while Open, got %v", err)
+	}
+
+	if atomic.LoadInt32(&probes) == 0 {
+		t.Errorf("expected health checks to run while Open")
+	}
+
+	healthy.Store(true)
+	time.Sleep(100 * time.Millisecond)
+
+	if cb.State() != breakr.HalfOpen {
+		t.Errorf("expected a successful probe to move the breaker to Half-Open, got %v", cb.State())
+	}
+}
+
+func TestHealthCheckClosesRamps(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold:    1,
+		ResetTimeout:        50 * time.Millisecond,
+		ExecutionTimeout:    500 * time.Millisecond,
+		RampDuration:        time.Second,
+		HealthCheckInterval: 50 * time.Millisecond,
+		HealthCheckCloses:   true,
+		HealthCheck: func(ctx context.Context) error {
+			return nil
+		},
+	})
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	if cb.State() != breakr.Ramping {
+		t.Errorf("expected a successful probe to recover through the ramp, got %v", cb.State())
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestRampUpAfterRecovery(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		RampDuration:     400 * time.Millisecond,
+	})
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	successFn := func() (interface{}, error) {
+		return "success", nil
+	}
+
+	_, _ = cb.Execute(failFn)
+	time.Sleep(150 * time.Millisecond)
+
+	if _, err := cb.Execute(successFn); err != nil {
+		t.Fatalf("expected half-open probe to succeed, got %v", err)
+	}
+
+	if cb.State() != breakr.Ramping {
+		t.Fatalf("expected Ramping after recovery, got %v", cb.State())
+	}
+
+	rejected := 0
+	for i := 0; i < 50; i++ {
+		if _, err := cb.Execute(successFn); errors.Is(err, breakr.ErrRampRejected) {
+			rejected++
+		}
+	}
+
+	if rejected == 0 {
+		t.Errorf("expected some calls to be rejected while ramping up")
+	}
+
+	if !errors.Is(breakr.ErrRampRejected, breakr.ErrCircuitOpen) {
+		t.Errorf("expected ramp rejections to match ErrCircuitOpen")
+	}
+
+	time.Sleep(400 * time.Millisecond)
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected Closed after the ramp, got %v", cb.State())
+	}
+}
+
+func TestRampUpFailureReopens(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		RampDuration:     time.Second,
+		RampMode:         config.RampExponential,
+	})
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(failFn)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return "success", nil
+	})
+
+	if cb.State() != breakr.Ramping {
+		t.Fatalf("expected Ramping after recovery, got %v", cb.State())
+	}
+
+	for cb.State() == breakr.Ramping {
+		_, _ = cb.Execute(failFn)
+	}
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected a failure during the ramp to reopen the breaker, got %v", cb.State())
+	}
+}
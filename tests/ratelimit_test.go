@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/ratelimit"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	rl := ratelimit.New(ratelimit.Config{Rate: 10, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if !rl.Allow() {
+			t.Fatalf("expected call %d to be allowed within burst", i)
+		}
+	}
+
+	if rl.Allow() {
+		t.Errorf("expected call above burst to be rejected")
+	}
+
+	time.Sleep(120 * time.Millisecond)
+
+	if !rl.Allow() {
+		t.Errorf("expected a token to be refilled")
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	rl := ratelimit.New(ratelimit.Config{Rate: 20, Burst: 1, Wait: true})
+
+	if err := rl.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected first acquire to succeed, got %v", err)
+	}
+
+	start := time.Now()
+	if err := rl.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected acquire to wait for a token, got %v", err)
+	}
+	if time.Since(start) < 40*time.Millisecond {
+		t.Errorf("expected acquire to wait for a token, waited %v", time.Since(start))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := rl.Acquire(ctx); !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Errorf("expected rate limited error before the deadline, got %v", err)
+	}
+}
+
+func TestCircuitBreakerRateLimit(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		RateLimit:        1,
+		RateBurst:        1,
+	})
+
+	successFn := func() (interface{}, error) {
+		return "success", nil
+	}
+
+	if _, err := cb.Execute(successFn); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := cb.Execute(successFn); !errors.Is(err, ratelimit.ErrRateLimited) {
+			t.Errorf("expected rate limited error, got %v", err)
+		}
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected rate limiting not to trip the breaker, got %v", cb.State())
+	}
+}
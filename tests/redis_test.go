@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/redis"
+)
+
+func newRedisShared(t *testing.T, addr string) *redis.Shared {
+	t.Helper()
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr, MaxRetries: -1})
+	shared := redis.New(redis.Config{
+		Client:        client,
+		RefreshPeriod: 20 * time.Millisecond,
+		RetryAfter:    50 * time.Millisecond,
+	})
+	t.Cleanup(func() {
+		_ = shared.Shutdown()
+		_ = client.Close()
+	})
+
+	return shared
+}
+
+func waitForState(t *testing.T, cb *breakr.Breaker, call func() (interface{}, error), want breakr.State) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		_, _ = cb.Execute(call)
+		if cb.State() == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected state %v, got %v", want, cb.State())
+}
+
+func TestRedisSharedFailures(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	cfg := config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+		SharedState:      newRedisShared(t, mr.Addr()),
+	}
+	first := breakr.New(cfg)
+
+	cfg.SharedState = newRedisShared(t, mr.Addr())
+	second := breakr.New(cfg)
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	_, _ = first.Execute(failFn)
+	_, _ = first.Execute(failFn)
+	if first.State() != breakr.Closed {
+		t.Fatalf("expected the first replica to stay closed, got %v", first.State())
+	}
+
+	_, _ = second.Execute(failFn)
+	if stats := second.Stats(); stats.State != breakr.Open || stats.TripReason != breakr.TripShared {
+		t.Fatalf("expected the shared window to open the second replica, got %+v", stats)
+	}
+
+	waitForState(t, first, successFn, breakr.Open)
+	if _, err := first.Execute(successFn); !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected the first replica to reject calls, got %v", err)
+	}
+}
+
+func TestRedisSharedRecovery(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	cfg := config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+		SharedState:      newRedisShared(t, mr.Addr()),
+	}
+	first := breakr.New(cfg)
+
+	cfg.SharedState = newRedisShared(t, mr.Addr())
+	second := breakr.New(cfg)
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 3; i++ {
+		_, _ = first.Execute(failFn)
+	}
+	if first.State() != breakr.Open {
+		t.Fatalf("expected the first replica to open, got %v", first.State())
+	}
+	if !mr.Exists("breakr:{payments}:state") {
+		t.Fatalf("expected the open state to be published to redis")
+	}
+
+	waitForState(t, second, successFn, breakr.Open)
+
+	time.Sleep(250 * time.Millisecond)
+	mr.FastForward(250 * time.Millisecond)
+
+	if _, err := first.Execute(successFn); err != nil {
+		t.Fatalf("expected the first replica to recover, got %v", err)
+	}
+	if first.State() != breakr.Closed {
+		t.Errorf("expected the first replica to close, got %v", first.State())
+	}
+	if mr.Exists("breakr:{payments}:state") {
+		t.Errorf("expected the recovery to clear the shared state")
+	}
+}
+
+func TestRedisUnavailableFallsBackToLocal(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	shared := newRedisShared(t, mr.Addr())
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+		SharedState:      shared,
+	})
+	mr.Close()
+
+	successFn := func() (interface{}, error) { return "ok", nil }
+	if _, err := cb.Execute(successFn); err != nil {
+		t.Fatalf("expected calls to succeed without redis, got %v", err)
+	}
+
+	if _, err := shared.RecordFailure("payments", 1, config.SharedWindow{Threshold: 1, Window: time.Second, OpenFor: time.Second}); !errors.Is(err, redis.ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the local breaker to open on its own, got %v", cb.State())
+	}
+}
+
+func TestRedisCloseFromAnotherReplica(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	first, second := newRedisShared(t, mr.Addr()), newRedisShared(t, mr.Addr())
+	w := config.SharedWindow{Threshold: 3, Window: time.Minute, OpenFor: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		if _, err := first.RecordFailure("payments", 1, w); err != nil {
+			t.Fatalf("failed to record failure: %v", err)
+		}
+	}
+
+	if err := second.Close("payments"); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	if mr.Exists("breakr:{payments}:failures") {
+		t.Fatalf("expected close to clear the failure counters")
+	}
+
+	if until, _ := first.RecordFailure("payments", 1, w); !until.IsZero() {
+		t.Errorf("expected the cleared window not to open the breaker, got %v", until)
+	}
+}
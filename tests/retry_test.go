@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/retry"
+)
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 5,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	var attempts []int
+	r := retry.New(retry.Policy{
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		Jitter:         0.5,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			attempts = append(attempts, attempt)
+		},
+	}, cb)
+
+	calls := 0
+	result, err := r.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		calls++
+		if retry.Attempt(ctx) != calls {
+			t.Errorf("expected attempt %d, got %d", calls, retry.Attempt(ctx))
+		}
+		if calls < 3 {
+			return nil, errors.New("error")
+		}
+		return "success", nil
+	})
+
+	if err != nil || result != "success" {
+		t.Fatalf("expected success, got %v, %v", result, err)
+	}
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("expected retries after attempts [1 2], got %v", attempts)
+	}
+}
+
+func TestRetryStopsOnOpenCircuit(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	r := retry.New(retry.Policy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Millisecond,
+	}, cb)
+
+	calls := 0
+	_, err := r.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, errors.New("error")
+	})
+
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected circuit open error, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls before the breaker opened, got %d", calls)
+	}
+}
+
+func TestRetryRespectsClassifierAndDeadline(t *testing.T) {
+	permanent := errors.New("permanent")
+
+	r := retry.New(retry.Policy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, permanent)
+		},
+	}, nil)
+
+	calls := 0
+	_, err := r.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, permanent
+	})
+
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("expected a single attempt for a non-retryable error, got %d calls, %v", calls, err)
+	}
+
+	r = retry.New(retry.Policy{
+		MaxAttempts:    5,
+		InitialBackoff: 200 * time.Millisecond,
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	calls = 0
+	_, _ = r.ExecuteCtx(ctx, func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, errors.New("error")
+	})
+
+	if calls != 1 {
+		t.Errorf("expected no retry past the context deadline, got %d calls", calls)
+	}
+}
+
+func TestRetryAttemptInEvents(t *testing.T) {
+	var attempts []int
+	cb := breakr.New(config.Config{
+		FailureThreshold: 5,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		OnEvent: func(e config.Event) {
+			attempts = append(attempts, e.Attempt)
+		},
+	})
+
+	r := retry.New(retry.Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}, cb)
+
+	_, _ = r.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("error")
+	})
+
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[1] != 2 || attempts[2] != 3 {
+		t.Errorf("expected events for attempts [1 2 3], got %v", attempts)
+	}
+}
@@ -0,0 +1,272 @@
+//go:build linux
+
+package tests
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/shm"
+	"github.com/genov8/breakr/trip"
+)
+
+func TestShmHelperProcess(t *testing.T) {
+	dir := os.Getenv("BREAKR_SHM_DIR")
+	if dir == "" {
+		t.Skip("helper process")
+	}
+
+	shared, err := shm.New(dir)
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+	defer func() { _ = shared.Shutdown() }()
+
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 4,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+		SharedState:      shared,
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+}
+
+func TestShmSharedAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	shared, err := shm.New(dir)
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+	defer func() { _ = shared.Shutdown() }()
+
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 4,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+		SharedState:      shared,
+	})
+
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestShmHelperProcess$")
+		cmd.Env = append(os.Environ(), "BREAKR_SHM_DIR="+dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("helper process failed: %v\n%s", err, out)
+		}
+	}
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected failures from other processes to open the breaker, got %v", cb.State())
+	}
+	if reason := cb.Stats().TripReason; reason != breakr.TripShared {
+		t.Errorf("expected trip reason %q, got %q", breakr.TripShared, reason)
+	}
+}
+
+func TestShmConcurrentFailures(t *testing.T) {
+	dir := t.TempDir()
+	shared, err := shm.New(dir)
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+	defer func() { _ = shared.Shutdown() }()
+
+	w := config.SharedWindow{Threshold: 100, Window: time.Minute, OpenFor: time.Minute}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 99; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = shared.RecordFailure("payments", 1, w)
+		}()
+	}
+	wg.Wait()
+
+	if until, _ := shared.OpenUntil("payments"); !until.IsZero() {
+		t.Fatalf("expected the breaker to stay closed below the threshold, got %v", until)
+	}
+
+	until, err := shared.RecordFailure("payments", 1, w)
+	if err != nil || until.IsZero() {
+		t.Fatalf("expected the 100th failure to open the breaker, got %v, %v", until, err)
+	}
+
+	if err := shared.Close("payments"); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	if got, _ := shared.OpenUntil("payments"); !got.Equal(until) {
+		t.Errorf("expected close to leave an unexpired open period alone, got %v", got)
+	}
+}
+
+func TestShmTakesOverStaleInitializer(t *testing.T) {
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot start a process: %v", err)
+	}
+	deadPid := cmd.Process.Pid
+
+	dir := t.TempDir()
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint64(header[0:], 1<<63|uint64(deadPid))
+	binary.LittleEndian.PutUint64(header[8:], uint64(time.Now().Add(time.Hour).UnixNano()))
+	if err := os.WriteFile(filepath.Join(dir, "payments.shm"), header, 0o644); err != nil {
+		t.Fatalf("failed to write segment: %v", err)
+	}
+
+	shared, err := shm.New(dir)
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+	defer func() { _ = shared.Shutdown() }()
+
+	until, err := shared.OpenUntil("payments")
+	if err != nil {
+		t.Fatalf("expected the stale initializer to be taken over, got %v", err)
+	}
+	if !until.IsZero() {
+		t.Errorf("expected a reinitialized segment, got open until %v", until)
+	}
+}
+
+func TestShmShutdownDuringUse(t *testing.T) {
+	shared, err := shm.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+
+	w := config.SharedWindow{Threshold: 1000, Window: time.Minute, OpenFor: time.Minute}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if _, err := shared.RecordFailure("payments", 1, w); errors.Is(err, shm.ErrClosed) {
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(time.Millisecond)
+	if err := shared.Shutdown(); err != nil {
+		t.Fatalf("failed to shut down: %v", err)
+	}
+	wg.Wait()
+
+	if _, err := shared.OpenUntil("payments"); !errors.Is(err, shm.ErrClosed) {
+		t.Errorf("expected ErrClosed after Shutdown, got %v", err)
+	}
+	if err := shared.Shutdown(); err != nil {
+		t.Errorf("expected a second Shutdown to be a no-op, got %v", err)
+	}
+}
+
+func TestShmSharedWithTripStrategy(t *testing.T) {
+	dir := t.TempDir()
+
+	var breakers []*breakr.Breaker
+	for i := 0; i < 2; i++ {
+		shared, err := shm.New(dir)
+		if err != nil {
+			t.Fatalf("failed to open shared memory: %v", err)
+		}
+		defer func() { _ = shared.Shutdown() }()
+
+		breakers = append(breakers, breakr.New(config.Config{
+			Name:             "payments",
+			ResetTimeout:     time.Minute,
+			ExecutionTimeout: 500 * time.Millisecond,
+			TripStrategy:     &trip.Consecutive{Failures: 5},
+			SharedState:      shared,
+		}))
+	}
+
+	_, _ = breakers[0].Execute(func() (interface{}, error) { return nil, errors.New("error") })
+
+	for i, cb := range breakers {
+		if cb.State() != breakr.Closed {
+			t.Errorf("expected replica %d to stay closed after one failure, got %v", i, cb.State())
+		}
+	}
+}
+
+type blockingStrategy struct {
+	trip.Consecutive
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStrategy) Record(o config.Outcome) {
+	close(s.entered)
+	<-s.release
+	s.Consecutive.Record(o)
+}
+
+func TestShmStateDoesNotTakeBreakerLock(t *testing.T) {
+	shared, err := shm.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open shared memory: %v", err)
+	}
+	defer func() { _ = shared.Shutdown() }()
+
+	strategy := &blockingStrategy{
+		Consecutive: trip.Consecutive{Failures: 5},
+		entered:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		TripStrategy:     strategy,
+		SharedState:      shared,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}()
+	<-strategy.entered
+
+	if err := shared.Open("payments", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("failed to open shared state: %v", err)
+	}
+
+	states := make(chan breakr.State, 1)
+	go func() { states <- cb.State() }()
+
+	select {
+	case state := <-states:
+		if state != breakr.Open {
+			t.Errorf("expected State to report the shared open, got %v", state)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("State blocked on the breaker lock")
+	}
+
+	close(strategy.release)
+	<-done
+
+	if stats := cb.Stats(); stats.State != breakr.Open || stats.TripReason != breakr.TripShared {
+		t.Errorf("expected Stats to adopt the shared open, got %v (%q)", stats.State, stats.TripReason)
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestSLOTripsOnBurnRate(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		SLOTarget:        0.9,
+		BurnRateWindows: []config.BurnRateWindow{
+			{Short: time.Minute, Long: time.Hour, Threshold: 5},
+		},
+	})
+
+	successFn := func() (interface{}, error) { return "ok", nil }
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+
+	for i := 0; i < 20; i++ {
+		_, _ = cb.Execute(successFn)
+	}
+	for i := 0; i < 5; i++ {
+		_, _ = cb.Execute(failFn)
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected the breaker to stay closed at a burn rate of 2, got %v", cb.State())
+	}
+	if budget := cb.ErrorBudgetRemaining(); math.Abs(budget+1) > 1e-9 {
+		t.Errorf("expected error budget remaining -1, got %v", budget)
+	}
+
+	for i := 0; i < 20; i++ {
+		_, _ = cb.Execute(failFn)
+		if cb.State() == breakr.Open {
+			break
+		}
+	}
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected the breaker to open once the burn rate exceeds 5, got %v", cb.State())
+	}
+}
+
+func TestSLOIgnoresSuccessfulTraffic(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		SLOTarget:        0.9,
+		BurnRateWindows: []config.BurnRateWindow{
+			{Short: time.Minute, Long: time.Hour, Threshold: 5},
+		},
+	})
+
+	for i := 0; i < 10; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+	}
+
+	if budget := cb.Stats().ErrorBudgetRemaining; budget != 1 {
+		t.Errorf("expected a full error budget, got %v", budget)
+	}
+}
+
+func TestSLOBudgetResetsAfterRecovery(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     100 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		SLOTarget:        0.9,
+		BurnRateWindows: []config.BurnRateWindow{
+			{Short: time.Minute, Long: time.Hour, Threshold: 5},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected the breaker to open, got %v", cb.State())
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected the breaker to close after recovery, got %v", cb.State())
+	}
+	if budget := cb.ErrorBudgetRemaining(); budget != 1 {
+		t.Errorf("expected the error budget to reset after recovery, got %v", budget)
+	}
+}
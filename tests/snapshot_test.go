@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func roundTrip(t *testing.T, s breakr.Snapshot) breakr.Snapshot {
+	t.Helper()
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("failed to marshal snapshot: %v", err)
+	}
+
+	var restored breakr.Snapshot
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("failed to unmarshal snapshot: %v", err)
+	}
+	return restored
+}
+
+func TestSnapshotRestoreOpen(t *testing.T) {
+	cfg := config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	}
+
+	cb := breakr.New(cfg)
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+
+	snapshot := roundTrip(t, cb.Snapshot())
+	if snapshot.State != "Open" || snapshot.OpenUntil == nil || len(snapshot.Failures) != 2 {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+
+	restored := breakr.New(cfg)
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	_, err := restored.Execute(func() (interface{}, error) { return "ok", nil })
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Fatalf("expected the restored breaker to reject calls, got %v", err)
+	}
+
+	stats := restored.Stats()
+	if stats.Failures != 2 || stats.TripReason != breakr.TripFailures || stats.LastFailure.IsZero() {
+		t.Errorf("expected failures and trip reason to be restored, got %+v", stats)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	if _, err := restored.Execute(func() (interface{}, error) { return "ok", nil }); err != nil {
+		t.Fatalf("expected the restored breaker to recover after the open period, got %v", err)
+	}
+	if restored.State() != breakr.Closed {
+		t.Errorf("expected Closed after recovery, got %v", restored.State())
+	}
+}
+
+func TestRestoreElapsedOpenPeriod(t *testing.T) {
+	cfg := config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	}
+
+	cb := breakr.New(cfg)
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	snapshot := cb.Snapshot()
+
+	time.Sleep(250 * time.Millisecond)
+
+	restored := breakr.New(cfg)
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	if restored.State() != breakr.HalfOpen {
+		t.Errorf("expected Half-Open when the open period has elapsed, got %v", restored.State())
+	}
+}
+
+func TestRestoreDropsExpiredFailures(t *testing.T) {
+	now := time.Now()
+	snapshot := breakr.Snapshot{
+		Version: 1,
+		State:   "Closed",
+		Failures: []breakr.SnapshotFailure{
+			{Time: now.Add(-2 * time.Minute), Weight: 1, Category: "error"},
+			{Time: now.Add(-time.Second), Weight: 1, Category: "timeout"},
+		},
+	}
+
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	})
+	if err := cb.Restore(snapshot); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	stats := cb.Stats()
+	if stats.Failures != 1 || stats.Timeouts != 1 || stats.State != breakr.Closed {
+		t.Errorf("expected 1 timeout within the window, got %+v", stats)
+	}
+}
+
+func TestRestoreInvalidSnapshot(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	})
+
+	if err := cb.Restore(breakr.Snapshot{Version: 99, State: "Closed"}); !errors.Is(err, breakr.ErrInvalidSnapshot) {
+		t.Errorf("expected ErrInvalidSnapshot for an unknown version, got %v", err)
+	}
+	if err := cb.Restore(breakr.Snapshot{Version: 1, State: "Broken"}); !errors.Is(err, breakr.ErrInvalidSnapshot) {
+		t.Errorf("expected ErrInvalidSnapshot for an unknown state, got %v", err)
+	}
+}
+
+func TestSnapshotBurnRate(t *testing.T) {
+	cfg := config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     200 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	}
+	cfg.SLOTarget = 0.9
+	cfg.BurnRateWindows = []config.BurnRateWindow{{Short: time.Minute, Long: time.Hour, Threshold: 100}}
+
+	cb := breakr.New(cfg)
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+
+	restored := breakr.New(cfg)
+	if err := restored.Restore(roundTrip(t, cb.Snapshot())); err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	if got, want := restored.ErrorBudgetRemaining(), cb.ErrorBudgetRemaining(); got != want {
+		t.Errorf("expected error budget %v after restore, got %v", want, got)
+	}
+}
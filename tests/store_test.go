@@ -0,0 +1,233 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/store"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	s, err := store.NewFile(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file store: %v", err)
+	}
+
+	if _, err := s.Load("payments/eu"); !errors.Is(err, config.ErrStateNotFound) {
+		t.Fatalf("expected ErrStateNotFound, got %v", err)
+	}
+
+	if err := s.Save("payments/eu", []byte(`{"version":1}`)); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	data, err := s.Load("payments/eu")
+	if err != nil || string(data) != `{"version":1}` {
+		t.Fatalf("expected saved state, got %q, %v", data, err)
+	}
+}
+
+func TestFileStoreConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	s, err := store.NewFile(dir)
+	if err != nil {
+		t.Fatalf("failed to create file store: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = s.Save("payments", []byte(fmt.Sprintf(`{"writer":%d}`, i)))
+		}(i)
+	}
+	wg.Wait()
+
+	data, err := s.Load("payments")
+	if err != nil || !strings.HasPrefix(string(data), `{"writer":`) || !strings.HasSuffix(string(data), "}") {
+		t.Fatalf("expected a complete state file, got %q, %v", data, err)
+	}
+
+	tmp, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if len(tmp) != 0 {
+		t.Errorf("expected no temporary files to be left behind, got %v", tmp)
+	}
+}
+
+func TestBreakerStateSurvivesRestart(t *testing.T) {
+	s, err := store.NewFile(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create file store: %v", err)
+	}
+
+	cfg := config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       s,
+	}
+
+	for run := 0; run < 3; run++ {
+		cb := breakr.New(cfg)
+		if cb.State() != breakr.Closed {
+			t.Fatalf("run %d: expected Closed, got %v", run, cb.State())
+		}
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+		cb.Flush()
+	}
+
+	cb := breakr.New(cfg)
+	if cb.State() != breakr.Open {
+		t.Fatalf("expected failures from earlier runs to open the breaker, got %v", cb.State())
+	}
+
+	_, err = cb.Execute(func() (interface{}, error) { return "ok", nil })
+	if !errors.Is(err, breakr.ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := store.NewMemory()
+
+	cfg := config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       s,
+	}
+
+	cb := breakr.New(cfg)
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+	cb.Flush()
+
+	restored := breakr.New(cfg)
+	if stats := restored.Stats(); stats.Failures != 0 || stats.Calls != 2 {
+		t.Errorf("expected the success to clear the persisted failures, got %+v", stats)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Load(string) ([]byte, error) {
+	return nil, os.ErrPermission
+}
+
+func (failingStore) Save(string, []byte) error {
+	return os.ErrPermission
+}
+
+func TestStateStoreErrorsKeepBreakerWorking(t *testing.T) {
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 3,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       failingStore{},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the breaker to open despite store errors, got %v", cb.State())
+	}
+}
+
+type blockingStore struct {
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStore) Load(string) ([]byte, error) {
+	return nil, config.ErrStateNotFound
+}
+
+func (s *blockingStore) Save(string, []byte) error {
+	s.saving <- struct{}{}
+	<-s.release
+	return nil
+}
+
+func TestSlowStateStoreDoesNotBlockBreaker(t *testing.T) {
+	s := &blockingStore{saving: make(chan struct{}, 1), release: make(chan struct{})}
+
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 1,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       s,
+	})
+
+	start := time.Now()
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Errorf("expected Execute not to wait for Save, took %v", d)
+	}
+
+	<-s.saving
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the breaker to open while Save is blocked, got %v", cb.State())
+	}
+
+	close(s.release)
+	cb.Flush()
+}
+
+func TestStateStoreDebouncesFailures(t *testing.T) {
+	var saves int32
+	s := countingStore{Memory: store.NewMemory(), saves: &saves}
+
+	cb := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 100,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       s,
+	})
+
+	for i := 0; i < 50; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	cb.Flush()
+
+	if n := atomic.LoadInt32(&saves); n == 0 || n > 2 {
+		t.Errorf("expected failures to be saved in one batch, got %d saves", n)
+	}
+
+	restored := breakr.New(config.Config{
+		Name:             "payments",
+		FailureThreshold: 100,
+		ResetTimeout:     time.Minute,
+		ExecutionTimeout: 500 * time.Millisecond,
+		StateStore:       s,
+	})
+	if stats := restored.Stats(); stats.Failures != 50 {
+		t.Errorf("expected all 50 failures to be saved, got %d", stats.Failures)
+	}
+}
+
+type countingStore struct {
+	*store.Memory
+	saves *int32
+}
+
+func (s countingStore) Save(name string, data []byte) error {
+	atomic.AddInt32(s.saves, 1)
+	return s.Memory.Save(name, data)
+}
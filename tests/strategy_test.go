@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+	"github.com/genov8/breakr/trip"
+)
+
+func TestWindowSizeKeepsFailuresAcrossSuccesses(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WindowSize:       time.Minute,
+	})
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(failFn)
+		if cb.State() == breakr.Closed {
+			_, _ = cb.Execute(successFn)
+		}
+	}
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected 3 failures within the window to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestConsecutiveStrategy(t *testing.T) {
+	cb := breakr.New(config.Config{
+		ResetTimeout:     50 * time.Millisecond,
+		ExecutionTimeout: 500 * time.Millisecond,
+		TripStrategy:     &trip.Consecutive{Failures: 3, Successes: 2},
+	})
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 5; i++ {
+		_, _ = cb.Execute(failFn)
+		_, _ = cb.Execute(failFn)
+		_, _ = cb.Execute(successFn)
+	}
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected interleaved successes to keep the breaker closed, got %v", cb.State())
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(failFn)
+	}
+
+	stats := cb.Stats()
+	if stats.State != breakr.Open || stats.TripReason != breakr.TripStrategy {
+		t.Fatalf("expected the strategy to open the breaker, got %+v", stats)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+
+	_, _ = cb.Execute(successFn)
+	if cb.State() != breakr.HalfOpen {
+		t.Fatalf("expected the breaker to stay half-open after 1 success, got %v", cb.State())
+	}
+
+	_, _ = cb.Execute(successFn)
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected the breaker to close after 2 successes, got %v", cb.State())
+	}
+}
+
+func TestWindowedStrategy(t *testing.T) {
+	cb := breakr.New(config.Config{
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		TripStrategy:     &trip.Windowed{Threshold: 3, Window: 100 * time.Millisecond},
+	})
+
+	failFn := func() (interface{}, error) { return nil, errors.New("error") }
+	successFn := func() (interface{}, error) { return "ok", nil }
+
+	_, _ = cb.Execute(failFn)
+	_, _ = cb.Execute(failFn)
+	time.Sleep(150 * time.Millisecond)
+
+	_, _ = cb.Execute(failFn)
+	_, _ = cb.Execute(successFn)
+	_, _ = cb.Execute(failFn)
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected expired failures not to count, got %v", cb.State())
+	}
+
+	_, _ = cb.Execute(successFn)
+	_, _ = cb.Execute(failFn)
+	if cb.State() != breakr.Open {
+		t.Errorf("expected 3 failures within the window to open the breaker, got %v", cb.State())
+	}
+}
+
+type recordingStrategy struct {
+	outcomes []config.Outcome
+	open     bool
+}
+
+func (s *recordingStrategy) Record(o config.Outcome) {
+	s.outcomes = append(s.outcomes, o)
+}
+
+func (s *recordingStrategy) ShouldOpen(time.Time) bool {
+	return s.open
+}
+
+func (s *recordingStrategy) ShouldClose(time.Time) bool {
+	return true
+}
+
+func (s *recordingStrategy) Reset() {}
+
+func TestCustomStrategy(t *testing.T) {
+	strategy := &recordingStrategy{}
+	cb := breakr.New(config.Config{
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		TripStrategy:     strategy,
+	})
+
+	for i := 0; i < 10; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected the strategy to keep the breaker closed, got %v", cb.State())
+	}
+	if len(strategy.outcomes) != 11 || !strategy.outcomes[0].Failed() || strategy.outcomes[10].Failed() {
+		t.Fatalf("expected 10 failures and 1 success to be recorded, got %+v", strategy.outcomes)
+	}
+
+	strategy.open = true
+	_, _ = cb.Execute(func() (interface{}, error) { return "ok", nil })
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the strategy to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestWindowedWithoutWindowKeepsRecentFailures(t *testing.T) {
+	w := &trip.Windowed{Threshold: 3}
+	now := time.Now()
+
+	for i := 0; i < 1000; i++ {
+		w.Record(config.Outcome{Time: now, Err: errors.New("error")})
+	}
+
+	if !w.ShouldOpen(now) {
+		t.Errorf("expected failures without a window to open the strategy")
+	}
+}
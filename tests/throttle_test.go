@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestThrottleMode(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		Mode:             config.ModeThrottle,
+		ThrottleWindow:   time.Minute,
+	})
+
+	successFn := func() (interface{}, error) {
+		return "success", nil
+	}
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := cb.Execute(successFn); err != nil {
+			t.Fatalf("expected success, got error: %v", err)
+		}
+	}
+
+	if p := cb.RejectionProbability(); p != 0 {
+		t.Errorf("expected no rejections while healthy, got probability %v", p)
+	}
+
+	throttled := 0
+	for i := 0; i < 100; i++ {
+		if _, err := cb.Execute(failFn); errors.Is(err, breakr.ErrThrottled) {
+			throttled++
+		}
+	}
+
+	if throttled == 0 {
+		t.Errorf("expected some calls to be throttled")
+	}
+
+	if throttled == 100 {
+		t.Errorf("expected some calls to still reach the dependency")
+	}
+
+	if p := cb.RejectionProbability(); p < 0.5 {
+		t.Errorf("expected a high rejection probability, got %v", p)
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected throttle mode not to open the breaker, got %v", cb.State())
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func slowCall(d time.Duration) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-time.After(d):
+			return "done", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestExecutionTimeoutWithCallerDeadline(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := cb.ExecuteCtx(ctx, slowCall(200*time.Millisecond))
+	if !errors.Is(err, breakr.ErrExecutionTimeout) {
+		t.Errorf("expected the breaker timeout to apply, got %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the breaker timeout to match context.DeadlineExceeded, got %v", err)
+	}
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the breaker timeout to count as a failure, got %v", cb.State())
+	}
+}
+
+func TestCallerDeadlineIsNotAFailure(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: time.Second,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cb.ExecuteCtx(ctx, slowCall(200*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, breakr.ErrExecutionTimeout) {
+		t.Errorf("expected the caller deadline error, got %v", err)
+	}
+
+	if cb.State() != breakr.Closed {
+		t.Errorf("expected the caller deadline not to count as a failure, got %v", cb.State())
+	}
+}
+
+func TestTimeoutCallerDeadlinePolicy(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 50 * time.Millisecond,
+		TimeoutPolicy:    config.TimeoutCallerDeadline,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := cb.ExecuteCtx(ctx, slowCall(100*time.Millisecond))
+	if err != nil || result != "done" {
+		t.Errorf("expected the caller deadline to replace ExecutionTimeout, got %v, %v", result, err)
+	}
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestWarmupCalls(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WarmupCalls:      5,
+	})
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	for i := 0; i < 4; i++ {
+		_, _ = cb.Execute(failFn)
+	}
+
+	stats := cb.Stats()
+	if stats.State != breakr.Closed {
+		t.Errorf("expected the breaker not to trip during warm-up, got %v", stats.State)
+	}
+	if !stats.WarmingUp || stats.Calls != 4 || stats.Failures != 4 {
+		t.Errorf("expected warm-up stats with 4 recorded failures, got %+v", stats)
+	}
+
+	_, _ = cb.Execute(failFn)
+
+	stats = cb.Stats()
+	if stats.WarmingUp {
+		t.Errorf("expected warm-up to end after 5 calls")
+	}
+	if stats.State != breakr.Open {
+		t.Errorf("expected the breaker to trip after warm-up, got %v", stats.State)
+	}
+}
+
+func TestWarmupDuration(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		WarmupDuration:   100 * time.Millisecond,
+	})
+
+	failFn := func() (interface{}, error) {
+		return nil, errors.New("error")
+	}
+
+	_, _ = cb.Execute(failFn)
+
+	if cb.State() != breakr.Closed || !cb.Stats().WarmingUp {
+		t.Errorf("expected Closed during warm-up, got %+v", cb.Stats())
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	_, _ = cb.Execute(failFn)
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected Open after warm-up, got %v", cb.State())
+	}
+}
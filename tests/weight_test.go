@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/genov8/breakr/config"
+	"github.com/genov8/breakr/internal/breakr"
+)
+
+func TestFailureWeightsDefaultToOne(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) {
+			return nil, &httpError{code: 500, msg: "internal error"}
+		})
+	}
+
+	stats := cb.Stats()
+	if stats.State != breakr.Closed || stats.FailureWeight != 2 {
+		t.Fatalf("expected a closed breaker with weight 2, got %+v", stats)
+	}
+
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the breaker to open after 3 failures, got %v", cb.State())
+	}
+}
+
+func TestFailureWeightsByClass(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 3,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		FailureWeights: map[string]float64{
+			"500":                                0.5,
+			config.FailureClassConnectionRefused: 3,
+		},
+	})
+
+	for i := 0; i < 5; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) {
+			return nil, &httpError{code: 500, msg: "internal error"}
+		})
+	}
+
+	if stats := cb.Stats(); stats.State != breakr.Closed || stats.FailureWeight != 2.5 {
+		t.Fatalf("expected a closed breaker with weight 2.5, got %+v", stats)
+	}
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, fmt.Errorf("dial: %w", syscall.ECONNREFUSED)
+	})
+
+	if cb.State() != breakr.Open {
+		t.Errorf("expected a refused connection to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestFailureWeightForTimeouts(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 2,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 20 * time.Millisecond,
+		FailureWeights: map[string]float64{
+			config.FailureClassTimeout: 2,
+		},
+	})
+
+	_, err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	if !errors.Is(err, breakr.ErrExecutionTimeout) {
+		t.Fatalf("expected ErrExecutionTimeout, got %v", err)
+	}
+	if cb.State() != breakr.Open {
+		t.Errorf("expected a single timeout to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestFailureWeightFunc(t *testing.T) {
+	cb := breakr.New(config.Config{
+		FailureThreshold: 1,
+		ResetTimeout:     time.Second,
+		ExecutionTimeout: 500 * time.Millisecond,
+		FailureWeight: func(err error) float64 {
+			return 0.25
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	}
+	if cb.State() != breakr.Closed {
+		t.Fatalf("expected the breaker to stay closed at weight 0.75, got %v", cb.State())
+	}
+
+	_, _ = cb.Execute(func() (interface{}, error) { return nil, errors.New("error") })
+	if cb.State() != breakr.Open {
+		t.Errorf("expected the breaker to open at weight 1, got %v", cb.State())
+	}
+}
@@ -0,0 +1,119 @@
+package trip
+
+import (
+	"time"
+
+	"github.com/genov8/breakr/config"
+)
+
+type Consecutive struct {
+	Failures  int
+	Successes int
+
+	failureRun int
+	successRun int
+}
+
+func (c *Consecutive) Record(o config.Outcome) {
+	if o.Failed() {
+		c.failureRun++
+		c.successRun = 0
+	} else {
+		c.successRun++
+		c.failureRun = 0
+	}
+}
+
+func (c *Consecutive) ShouldOpen(time.Time) bool {
+	failures := c.Failures
+	if failures <= 0 {
+		failures = 1
+	}
+	return c.failureRun >= failures
+}
+
+func (c *Consecutive) ShouldClose(time.Time) bool {
+	successes := c.Successes
+	if successes <= 0 {
+		successes = 1
+	}
+	return c.successRun >= successes
+}
+
+func (c *Consecutive) Reset() {
+	c.failureRun = 0
+	c.successRun = 0
+}
+
+type Windowed struct {
+	Threshold float64
+	Window    time.Duration
+	Successes int
+
+	failures   []config.Outcome
+	successRun int
+}
+
+func (w *Windowed) Record(o config.Outcome) {
+	if !o.Failed() {
+		w.successRun++
+		return
+	}
+
+	w.successRun = 0
+	if o.Weight == 0 {
+		o.Weight = 1
+	}
+	w.failures = append(w.failures, o)
+	w.prune(o.Time)
+}
+
+func (w *Windowed) ShouldOpen(now time.Time) bool {
+	w.prune(now)
+	return w.weight() >= w.threshold()
+}
+
+func (w *Windowed) ShouldClose(time.Time) bool {
+	successes := w.Successes
+	if successes <= 0 {
+		successes = 1
+	}
+	return w.successRun >= successes
+}
+
+func (w *Windowed) Reset() {
+	w.failures = nil
+	w.successRun = 0
+}
+
+func (w *Windowed) prune(now time.Time) {
+	i := 0
+	if w.Window > 0 {
+		cutoff := now.Add(-w.Window)
+		for i < len(w.failures) && !w.failures[i].Time.After(cutoff) {
+			i++
+		}
+	} else {
+		weight := w.weight()
+		for i < len(w.failures)-1 && weight-w.failures[i].Weight >= w.threshold() {
+			weight -= w.failures[i].Weight
+			i++
+		}
+	}
+	w.failures = w.failures[i:]
+}
+
+func (w *Windowed) weight() float64 {
+	weight := 0.0
+	for _, o := range w.failures {
+		weight += o.Weight
+	}
+	return weight
+}
+
+func (w *Windowed) threshold() float64 {
+	if w.Threshold <= 0 {
+		return 1
+	}
+	return w.Threshold
+}